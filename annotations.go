@@ -0,0 +1,43 @@
+package twitter
+
+// ContextAnnotation assigns a tweet to an entity within a topic domain
+type ContextAnnotation struct {
+	Domain AnnotationDomain `json:"domain"`
+	Entity AnnotationEntity `json:"entity"`
+}
+
+// AnnotationDomain is the category of a ContextAnnotation, e.g. "Brand" or "Person"
+type AnnotationDomain struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// AnnotationEntity is the concrete topic of a ContextAnnotation, e.g. a company or a person
+type AnnotationEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// HasContextEntity reports whether the tweet is annotated with the entity
+func (t Tweet) HasContextEntity(entityID string) bool {
+	for _, annotation := range t.ContextAnnotations {
+		if annotation.Entity.ID == entityID {
+			return true
+		}
+	}
+	return false
+}
+
+// ContextFilter matches tweets annotated with the entity of the domain.
+// It can be passed to CreateStreamRule and SearchRecent like ImageFilter
+func ContextFilter(domainID, entityID string) string {
+	return "context:" + domainID + "." + entityID
+}
+
+// LangFilter matches tweets classified as being in the language given by its BCP 47 code.
+// It can be passed to CreateStreamRule and SearchRecent like ImageFilter
+func LangFilter(lang string) string {
+	return "lang:" + lang
+}
@@ -0,0 +1,29 @@
+package twitter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConvertToTweetAnnotations(t *testing.T) {
+
+	var response tweetResponse
+	err := json.Unmarshal([]byte(`{
+		"data": {"id": "1", "text": "new phone", "lang": "en", "context_annotations": [{
+			"domain": {"id": "47", "name": "Brand", "description": "Brands and Companies"},
+			"entity": {"id": "10026378521", "name": "Google"}
+		}]}
+	}`), &response)
+	equals(err, nil)
+
+	niceTweet := convertToTweet(response.Tweet, response.Includes, nil)
+	equals(niceTweet.Lang, "en")
+	equals(len(niceTweet.ContextAnnotations), 1)
+	equals(niceTweet.ContextAnnotations[0].Domain.Name, "Brand")
+	equals(niceTweet.ContextAnnotations[0].Entity.Name, "Google")
+	equals(niceTweet.HasContextEntity("10026378521"), true)
+	equals(niceTweet.HasContextEntity("1"), false)
+
+	equals(ContextFilter("47", "10026378521"), "context:47.10026378521")
+	equals(LangFilter("de"), "lang:de")
+}
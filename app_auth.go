@@ -0,0 +1,121 @@
+package twitter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	appTokenURL           = "https://api.twitter.com/oauth2/token"
+	appInvalidateTokenURL = "https://api.twitter.com/oauth2/invalidate_token"
+)
+
+// AppCredentials authenticates requests with an app-only bearer token obtained from the apps consumer key
+// and secret. The token is requested on first use and cached until Invalidate is called
+type AppCredentials struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	HTTPClient     *http.Client // used for token requests if set
+	token          string
+	sync.Mutex
+}
+
+// NewFromCredentials creates a Client that authenticates with a bearer token obtained using the apps
+// consumer key and secret. The token is requested immediately, so invalid credentials are reported here
+func NewFromCredentials(consumerKey, consumerSecret string) (Client, error) {
+	credentials := &AppCredentials{ConsumerKey: consumerKey, ConsumerSecret: consumerSecret}
+	if _, err := credentials.Token(); err != nil {
+		return Client{}, err
+	}
+	return NewWithAuth(credentials), nil
+}
+
+// Authenticate sets the Authorization header, requesting a token first if none is cached
+func (a *AppCredentials) Authenticate(req *http.Request) error {
+	token, err := a.Token()
+	if err != nil {
+		return err
+	}
+	return BearerToken(token).Authenticate(req)
+}
+
+// Token returns the cached bearer token or requests a new one from the oauth2/token endpoint
+func (a *AppCredentials) Token() (string, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.token != "" {
+		return a.token, nil
+	}
+
+	var response struct {
+		TokenType   string `json:"token_type"`
+		AccessToken string `json:"access_token"`
+	}
+	err := a.post(appTokenURL, url.Values{"grant_type": {"client_credentials"}}, &response)
+	if err != nil {
+		return "", err
+	}
+	if response.TokenType != "bearer" {
+		return "", fmt.Errorf("got unexpected token type %q", response.TokenType)
+	}
+
+	a.token = response.AccessToken
+	return a.token, nil
+}
+
+// Invalidate revokes the cached token using the oauth2/invalidate_token endpoint.
+// The next request obtains a new token
+func (a *AppCredentials) Invalidate() error {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.token == "" {
+		return nil
+	}
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+	}
+	err := a.post(appInvalidateTokenURL, url.Values{"access_token": {a.token}}, &response)
+	if err != nil {
+		return err
+	}
+
+	a.token = ""
+	return nil
+}
+
+// post sends the form to uri using basic authentication with the consumer key and secret
+func (a *AppCredentials) post(uri string, form url.Values, v interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, uri, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=UTF-8")
+	credentials := percentEncode(a.ConsumerKey) + ":" + percentEncode(a.ConsumerSecret)
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+
+	client := a.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	result, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer result.Body.Close()
+
+	if result.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(result.Body)
+		return fmt.Errorf("token request failed. Response: %s", string(data))
+	}
+
+	return json.NewDecoder(result.Body).Decode(v)
+}
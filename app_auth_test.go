@@ -0,0 +1,44 @@
+package twitter
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAppCredentials(t *testing.T) {
+
+	var tokenRequests, invalidations int
+	httpClient := newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		equals(ok, true)
+		equals(user, "key")
+		equals(password, "secret")
+
+		switch r.URL.Path {
+		case "/oauth2/token":
+			tokenRequests++
+			equals(r.FormValue("grant_type"), "client_credentials")
+			w.Write([]byte(`{"token_type": "bearer", "access_token": "apptoken"}`))
+		case "/oauth2/invalidate_token":
+			invalidations++
+			equals(r.FormValue("access_token"), "apptoken")
+			w.Write([]byte(`{"access_token": "apptoken"}`))
+		}
+	})
+
+	credentials := &AppCredentials{ConsumerKey: "key", ConsumerSecret: "secret", HTTPClient: httpClient}
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.twitter.com/2/tweets/1", nil)
+		equals(credentials.Authenticate(req), nil)
+		equals(req.Header.Get("Authorization"), "Bearer apptoken")
+	}
+	equals(tokenRequests, 1)
+
+	equals(credentials.Invalidate(), nil)
+	equals(invalidations, 1)
+
+	_, err := credentials.Token()
+	equals(err, nil)
+	equals(tokenRequests, 2)
+}
@@ -0,0 +1,27 @@
+package twitter
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Authenticator adds credentials to requests sent by a Client
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// BearerToken authenticates requests with an app-only bearer token. It is used for Client.Token
+type BearerToken string
+
+// Authenticate sets the Authorization header
+func (t BearerToken) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+string(t))
+	return nil
+}
+
+// percentEncode escapes s as required by OAuth (RFC 3986), which differs from url.QueryEscape
+// in the handling of spaces and the tilde
+func percentEncode(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(url.QueryEscape(s), "+", "%20"), "%7E", "~")
+}
@@ -0,0 +1,180 @@
+package twitter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Compliance job types
+const (
+	ComplianceTweets = "tweets"
+	ComplianceUsers  = "users"
+)
+
+// Compliance job states used by ComplianceJob.Status
+const (
+	ComplianceCreated    = "created"
+	ComplianceInProgress = "in_progress"
+	ComplianceComplete   = "complete"
+	ComplianceFailed     = "failed"
+	ComplianceExpired    = "expired"
+)
+
+// Compliance actions used by ComplianceAction.Action
+const (
+	ComplianceDelete   = "delete"
+	ComplianceProtect  = "protect"
+	ComplianceSuspend  = "suspend"
+	ComplianceScrubGeo = "scrub_geo"
+)
+
+// ComplianceJob is a batch compliance job that reports which of the uploaded tweet or user IDs
+// need to be changed or removed from storage
+type ComplianceJob struct {
+	ID                string `json:"id"`
+	Type              string `json:"type"`
+	Name              string `json:"name,omitempty"`
+	Status            string `json:"status"`
+	Resumable         bool   `json:"resumable"`
+	UploadURL         string `json:"upload_url"`
+	UploadExpiresAt   string `json:"upload_expires_at"`
+	DownloadURL       string `json:"download_url"`
+	DownloadExpiresAt string `json:"download_expires_at"`
+	CreatedAt         string `json:"created_at"`
+}
+
+// ComplianceAction is one line of the result of a compliance job
+type ComplianceAction struct {
+	ID         string `json:"id"`
+	Action     string `json:"action"`
+	Reason     string `json:"reason,omitempty"`
+	CreatedAt  string `json:"created_at"`
+	RedactedAt string `json:"redacted_at,omitempty"`
+}
+
+// CreateComplianceJob creates a job of type ComplianceTweets or ComplianceUsers
+func (tw *Client) CreateComplianceJob(jobType, name string) (job ComplianceJob, err error) {
+	body := struct {
+		Type string `json:"type"`
+		Name string `json:"name,omitempty"`
+	}{jobType, name}
+
+	var response struct {
+		Job ComplianceJob `json:"data"`
+	}
+	err = tw.sendJSON(http.MethodPost, fmt.Sprintf("%s/compliance/jobs", apiRoot), &body, &response)
+	return response.Job, err
+}
+
+// GetComplianceJob returns the current state of the job
+func (tw *Client) GetComplianceJob(jobID string) (job ComplianceJob, err error) {
+	var response struct {
+		Job ComplianceJob `json:"data"`
+	}
+	err = tw.getJSON(fmt.Sprintf("%s/compliance/jobs/%s", apiRoot, jobID), &response)
+	return response.Job, err
+}
+
+// GetComplianceJobs returns the jobs of the type, optionally filtered by status
+func (tw *Client) GetComplianceJobs(jobType, status string) (jobs []ComplianceJob, err error) {
+	uri := fmt.Sprintf("%s/compliance/jobs?type=%s", apiRoot, jobType)
+	if status != "" {
+		uri += "&status=" + status
+	}
+
+	var response struct {
+		Jobs []ComplianceJob `json:"data"`
+	}
+	err = tw.getJSON(uri, &response)
+	return response.Jobs, err
+}
+
+// UploadComplianceIDs uploads the tweet or user IDs to check to the UploadURL of the job
+func (tw *Client) UploadComplianceIDs(job ComplianceJob, ids []string) error {
+	req, err := http.NewRequest(http.MethodPut, job.UploadURL, strings.NewReader(strings.Join(ids, "\n")))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	// the upload url is pre-signed and must not be sent with credentials
+	result, err := tw.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer result.Body.Close()
+
+	if result.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(result.Body)
+		return fmt.Errorf("failed to upload compliance ids. Response: %s", string(data))
+	}
+	return nil
+}
+
+// WaitForComplianceJob polls the job every interval until it is complete.
+// It returns an error if the job failed or expired
+func (tw *Client) WaitForComplianceJob(ctx context.Context, jobID string, interval time.Duration) (job ComplianceJob, err error) {
+	for {
+		job, err = tw.GetComplianceJob(jobID)
+		if err != nil {
+			return
+		}
+
+		switch job.Status {
+		case ComplianceComplete:
+			return job, nil
+		case ComplianceFailed, ComplianceExpired:
+			return job, fmt.Errorf("compliance job %s %s", job.ID, job.Status)
+		}
+
+		if err = sleep(ctx, interval); err != nil {
+			return
+		}
+	}
+}
+
+// DownloadComplianceResults downloads and parses the result of a complete job
+func (tw *Client) DownloadComplianceResults(job ComplianceJob) ([]ComplianceAction, error) {
+	req, err := http.NewRequest(http.MethodGet, job.DownloadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// the download url is pre-signed and must not be sent with credentials
+	result, err := tw.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Body.Close()
+
+	if result.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(result.Body)
+		return nil, fmt.Errorf("failed to download compliance results. Response: %s", string(data))
+	}
+	return ParseComplianceResults(result.Body)
+}
+
+// ParseComplianceResults parses the newline delimited json result of a compliance job
+func ParseComplianceResults(r io.Reader) (actions []ComplianceAction, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var action ComplianceAction
+		if err = json.Unmarshal([]byte(line), &action); err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	return actions, scanner.Err()
+}
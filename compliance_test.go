@@ -0,0 +1,68 @@
+package twitter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestComplianceJob(t *testing.T) {
+
+	var mutex sync.Mutex
+	var uploaded string
+	statusRequests := 0
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /2/compliance/jobs":
+			w.Write([]byte(`{"data": {"id": "j1", "type": "tweets", "status": "created",
+				"upload_url": "https://storage.example.org/upload", "download_url": "https://storage.example.org/download"}}`))
+		case "PUT /upload":
+			equals(r.Header.Get("Authorization"), "")
+			equals(r.Header.Get("Content-Type"), "text/plain")
+			data, _ := ioutil.ReadAll(r.Body)
+			uploaded = string(data)
+		case "GET /2/compliance/jobs/j1":
+			statusRequests++
+			status := "in_progress"
+			if statusRequests > 1 {
+				status = "complete"
+			}
+			w.Write([]byte(`{"data": {"id": "j1", "status": "` + status + `",
+				"download_url": "https://storage.example.org/download"}}`))
+		case "GET /download":
+			equals(r.Header.Get("Authorization"), "")
+			w.Write([]byte(`{"id": "1", "action": "delete", "created_at": "2021-07-01T00:00:00.000Z", "reason": "deleted"}
+{"id": "2", "action": "scrub_geo", "created_at": "2021-07-01T00:00:00.000Z", "redacted_at": "2021-07-02T00:00:00.000Z"}
+
+`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	job, err := tw.CreateComplianceJob(ComplianceTweets, "")
+	equals(err, nil)
+	equals(job.Status, ComplianceCreated)
+
+	equals(tw.UploadComplianceIDs(job, []string{"1", "2", "3"}), nil)
+	equals(uploaded, "1\n2\n3")
+
+	job, err = tw.WaitForComplianceJob(context.Background(), job.ID, time.Millisecond)
+	equals(err, nil)
+	equals(job.Status, ComplianceComplete)
+	equals(statusRequests, 2)
+
+	actions, err := tw.DownloadComplianceResults(job)
+	equals(err, nil)
+	equals(len(actions), 2)
+	equals(actions[0].Action, ComplianceDelete)
+	equals(actions[0].Reason, "deleted")
+	equals(actions[1].Action, ComplianceScrubGeo)
+	equals(actions[1].RedactedAt, "2021-07-02T00:00:00.000Z")
+}
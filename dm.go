@@ -0,0 +1,220 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const dmEventFields = "dm_event.fields=id,text,event_type,created_at,dm_conversation_id,sender_id,participant_ids,attachments"
+
+// DM event types used by DMEvent.EventType
+const (
+	DMMessageCreate     = "MessageCreate"
+	DMParticipantsJoin  = "ParticipantsJoin"
+	DMParticipantsLeave = "ParticipantsLeave"
+)
+
+// DMEvent is a message or a change of participants in a direct message conversation
+type DMEvent struct {
+	ID             string   `json:"id"`
+	EventType      string   `json:"event_type"`
+	Text           string   `json:"text,omitempty"`
+	CreatedAt      string   `json:"created_at"`
+	ConversationID string   `json:"dm_conversation_id"`
+	SenderID       string   `json:"sender_id,omitempty"`
+	ParticipantIDs []string `json:"participant_ids,omitempty"`
+	Attachments    struct {
+		MediaKeys []string `json:"media_keys,omitempty"`
+	} `json:"attachments"`
+}
+
+// DMMessage is the content of a direct message to send
+type DMMessage struct {
+	Text     string
+	MediaIDs []string // IDs of uploaded media, see UploadMedia
+}
+
+// SentDM identifies a sent direct message
+type SentDM struct {
+	ConversationID string `json:"dm_conversation_id"`
+	EventID        string `json:"dm_event_id"`
+}
+
+// DMEventPage is one page of direct message events, newest first
+type DMEventPage struct {
+	Events    []DMEvent
+	NextToken string // pass as PageOptions.PaginationToken to get the next page, empty on the last page
+}
+
+// dmMessageRequest is the message part of the requests that send direct messages
+type dmMessageRequest struct {
+	Text        string         `json:"text,omitempty"`
+	Attachments []dmAttachment `json:"attachments,omitempty"`
+}
+
+type dmAttachment struct {
+	MediaID string `json:"media_id"`
+}
+
+func newDMMessageRequest(message DMMessage) dmMessageRequest {
+	req := dmMessageRequest{Text: message.Text}
+	for _, mediaID := range message.MediaIDs {
+		req.Attachments = append(req.Attachments, dmAttachment{mediaID})
+	}
+	return req
+}
+
+// SendDM sends a message to the one-to-one conversation with the user, creating it if needed
+func (tw *Client) SendDM(participantID string, message DMMessage) (SentDM, error) {
+	uri := fmt.Sprintf("%s/dm_conversations/with/%s/messages", apiRoot, participantID)
+	return tw.sendDM(uri, newDMMessageRequest(message))
+}
+
+// SendDMToConversation sends a message to an existing one-to-one or group conversation
+func (tw *Client) SendDMToConversation(conversationID string, message DMMessage) (SentDM, error) {
+	uri := fmt.Sprintf("%s/dm_conversations/%s/messages", apiRoot, conversationID)
+	return tw.sendDM(uri, newDMMessageRequest(message))
+}
+
+// CreateGroupDM creates a group conversation with the users and sends the first message
+func (tw *Client) CreateGroupDM(participantIDs []string, message DMMessage) (SentDM, error) {
+	body := struct {
+		ConversationType string           `json:"conversation_type"`
+		ParticipantIDs   []string         `json:"participant_ids"`
+		Message          dmMessageRequest `json:"message"`
+	}{"Group", participantIDs, newDMMessageRequest(message)}
+	return tw.sendDM(fmt.Sprintf("%s/dm_conversations", apiRoot), body)
+}
+
+func (tw *Client) sendDM(uri string, body interface{}) (sent SentDM, err error) {
+	var response struct {
+		Data SentDM `json:"data"`
+	}
+	err = tw.sendJSON(http.MethodPost, uri, body, &response)
+	return response.Data, err
+}
+
+// GetDMEvents returns the direct message events of all conversations of the authenticated user
+func (tw *Client) GetDMEvents(opts PageOptions) (DMEventPage, error) {
+	return tw.getDMEvents(fmt.Sprintf("%s/dm_events", apiRoot), opts)
+}
+
+// GetDMEventsWith returns the direct message events of the one-to-one conversation with the user
+func (tw *Client) GetDMEventsWith(participantID string, opts PageOptions) (DMEventPage, error) {
+	return tw.getDMEvents(fmt.Sprintf("%s/dm_conversations/with/%s/dm_events", apiRoot, participantID), opts)
+}
+
+// GetConversationDMEvents returns the direct message events of the conversation
+func (tw *Client) GetConversationDMEvents(conversationID string, opts PageOptions) (DMEventPage, error) {
+	return tw.getDMEvents(fmt.Sprintf("%s/dm_conversations/%s/dm_events", apiRoot, conversationID), opts)
+}
+
+func (tw *Client) getDMEvents(endpoint string, opts PageOptions) (page DMEventPage, err error) {
+	var response struct {
+		Events []DMEvent `json:"data"`
+		Meta   meta      `json:"meta"`
+	}
+	err = tw.getJSON(withQuery(endpoint, opts.query(), dmEventFields), &response)
+	if err != nil {
+		return
+	}
+	return DMEventPage{Events: response.Events, NextToken: response.Meta.NextToken}, nil
+}
+
+// DMWatcher contains a channel Events which receives new direct message events of the authenticated user
+type DMWatcher struct {
+	Events chan DMEvent
+	cancel context.CancelFunc
+}
+
+// WatchDMEvents polls the direct message events of the authenticated user every interval and sends
+// events that arrived after the watcher was started to DMWatcher.Events, oldest first.
+// Polling stops when ctx is done or Stop is called, after which Events is closed
+func (tw *Client) WatchDMEvents(ctx context.Context, interval time.Duration) *DMWatcher {
+	ctx, cancel := context.WithCancel(ctx)
+	watcher := &DMWatcher{Events: make(chan DMEvent), cancel: cancel}
+	go tw.watchDMEvents(ctx, interval, watcher.Events)
+	return watcher
+}
+
+// Stop ends polling and closes Events
+func (w *DMWatcher) Stop() {
+	w.cancel()
+}
+
+func (tw *Client) watchDMEvents(ctx context.Context, interval time.Duration, events chan<- DMEvent) {
+	defer close(events)
+
+	newestID := ""
+	started := false
+	for {
+		fresh, err := collectDMEventsSince(tw.GetDMEvents, newestID)
+
+		var rateLimitErr *RateLimitError
+		switch {
+		case errors.As(err, &rateLimitErr):
+			if rateLimitErr.Wait(ctx) != nil {
+				return
+			}
+			continue
+		case err != nil:
+			tw.logger.Printf("failed to poll dm events: %s", err)
+		case !started:
+			// only deliver events that arrive from now on
+			if len(fresh) > 0 {
+				newestID = fresh[0].ID
+			}
+			started = true
+		default:
+			for i := len(fresh) - 1; i >= 0; i-- {
+				select {
+				case events <- fresh[i]:
+					newestID = fresh[i].ID
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+
+		if sleep(ctx, interval) != nil {
+			return
+		}
+	}
+}
+
+// collectDMEventsSince fetches pages of direct message events until it reaches sinceID, newest first.
+// Without a sinceID only the newest page is fetched
+func collectDMEventsSince(fetch func(PageOptions) (DMEventPage, error), sinceID string) (events []DMEvent, err error) {
+	var opts PageOptions
+	for {
+		page, err := fetch(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range page.Events {
+			if sinceID != "" && !newerID(event.ID, sinceID) {
+				return events, nil
+			}
+			events = append(events, event)
+		}
+
+		if page.NextToken == "" || sinceID == "" {
+			return events, nil
+		}
+		opts.PaginationToken = page.NextToken
+	}
+}
+
+// newerID reports whether the snowflake ID a is newer than b. Every ID is newer than an empty one
+func newerID(a, b string) bool {
+	if b == "" {
+		return a != ""
+	}
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a > b
+}
@@ -0,0 +1,65 @@
+package twitter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSendDM(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.URL.Path, "/2/dm_conversations/with/42/messages")
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		equals(body["text"], "hello")
+		equals(body["attachments"].([]interface{})[0].(map[string]interface{})["media_id"], "m1")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"dm_conversation_id": "1-42", "dm_event_id": "100"}}`))
+	})
+
+	sent, err := tw.SendDM("42", DMMessage{Text: "hello", MediaIDs: []string{"m1"}})
+	equals(err, nil)
+	equals(sent.ConversationID, "1-42")
+	equals(sent.EventID, "100")
+}
+
+func TestWatchDMEvents(t *testing.T) {
+
+	var mutex sync.Mutex
+	responses := []string{
+		`{"data": [{"id": "100", "event_type": "MessageCreate", "text": "old"}], "meta": {"next_token": "older"}}`,
+		`{"data": [{"id": "103", "text": "third"}, {"id": "102", "text": "second"}], "meta": {"next_token": "p2"}}`,
+		`{"data": [{"id": "101", "text": "first"}, {"id": "100", "text": "old"}], "meta": {"next_token": "p3"}}`,
+		`{"data": [{"id": "103", "text": "third"}], "meta": {"next_token": "p2"}}`,
+	}
+	tokens := []string{"", "", "p2", ""}
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		equals(r.URL.Path, "/2/dm_events")
+		equals(r.URL.Query().Get("pagination_token"), tokens[0])
+		w.Write([]byte(responses[0]))
+		if len(responses) > 1 {
+			responses = responses[1:]
+			tokens = tokens[1:]
+		}
+	})
+
+	// events spread over several pages are delivered, stopping at the newest known event
+	watcher := tw.WatchDMEvents(context.Background(), time.Millisecond)
+	equals((<-watcher.Events).Text, "first")
+	equals((<-watcher.Events).Text, "second")
+	equals((<-watcher.Events).Text, "third")
+
+	watcher.Stop()
+	for range watcher.Events {
+	}
+
+	equals(newerID("1000", "999"), true)
+	equals(newerID("999", "1000"), false)
+	equals(newerID("5", ""), true)
+}
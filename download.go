@@ -0,0 +1,261 @@
+package twitter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+)
+
+const defaultDownloadConcurrency = 4
+
+// Downloader fetches the media attached to tweets.
+// The zero value is ready to use and downloads the highest bitrate mp4 of videos and GIFs
+type Downloader struct {
+	HTTPClient  *http.Client   // defaults to http.DefaultClient
+	Concurrency int            // maximum number of parallel downloads in DownloadTweet, defaults to 4
+	Variant     VariantOptions // used to pick the video variant to download
+}
+
+// MediaDownload is a resolved media file that can be downloaded
+type MediaDownload struct {
+	Key  string
+	Type string
+	URL  string
+}
+
+// DownloadResult reports a finished download
+type DownloadResult struct {
+	Key    string
+	URL    string
+	Path   string // empty if the media was written to an io.Writer
+	Size   int64
+	SHA256 string // hex encoded checksum of the complete file
+	Err    error  // only set by DownloadTweet
+}
+
+// Resolve returns the download URLs for every media item of the tweet.
+// Photos are requested in their original size and videos and GIFs are resolved to a variant
+// using the Downloaders VariantOptions. Media without a matching variant is left out
+func (d *Downloader) Resolve(tweet Tweet) []MediaDownload {
+	var downloads []MediaDownload
+	for _, item := range tweet.Media {
+		switch item.Type {
+		case MediaTypePhoto:
+			downloads = append(downloads, MediaDownload{item.Key, item.Type, originalImageURL(item.URL)})
+		case MediaTypeVideo, MediaTypeAnimatedGIF:
+			variant, err := item.SelectVariant(d.Variant)
+			if err != nil {
+				continue
+			}
+			downloads = append(downloads, MediaDownload{item.Key, item.Type, variant.URL})
+		}
+	}
+	return downloads
+}
+
+// originalImageURL adds name=orig to an image url to request the full resolution version
+func originalImageURL(imageURL string) string {
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return imageURL
+	}
+	query := u.Query()
+	query.Set("name", "orig")
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// Download streams the media to w and returns its size and checksum
+func (d *Downloader) Download(ctx context.Context, media MediaDownload, w io.Writer) (result DownloadResult, err error) {
+	result = DownloadResult{Key: media.Key, URL: media.URL}
+
+	resp, err := d.get(ctx, media.URL, 0)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("failed to download %s. Status: %s", media.URL, resp.Status)
+	}
+
+	checksum := sha256.New()
+	result.Size, err = io.Copy(io.MultiWriter(w, checksum), resp.Body)
+	if err != nil {
+		return
+	}
+	result.SHA256 = hex.EncodeToString(checksum.Sum(nil))
+	return result, nil
+}
+
+// DownloadToDir saves the media in dir, named after the SHA-256 checksum of its content.
+// Data is first written to a <media key>.part file. If such a file is left over from an interrupted
+// download, only the missing bytes are requested using a Range header
+func (d *Downloader) DownloadToDir(ctx context.Context, media MediaDownload, dir string) (result DownloadResult, err error) {
+	result = DownloadResult{Key: media.Key, URL: media.URL}
+
+	partPath := filepath.Join(dir, media.Key+".part")
+	file, err := os.OpenFile(partPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// the checksum covers the whole file, so already downloaded bytes are hashed first
+	checksum := sha256.New()
+	offset, err := io.Copy(checksum, file)
+	if err != nil {
+		return
+	}
+
+	resp, err := d.get(ctx, media.URL, offset)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusPartialContent && contentRangeStart(resp.Header.Get("Content-Range")) != offset {
+		// the server sent another range than requested, start over with the whole file
+		resp.Body.Close()
+		if err = restart(file, &checksum); err != nil {
+			return
+		}
+		offset = 0
+
+		resp, err = d.get(ctx, media.URL, 0)
+		if err != nil {
+			return
+		}
+		defer resp.Body.Close()
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		// append to the existing data
+	case http.StatusOK:
+		// the server ignored the range, start over
+		if err = restart(file, &checksum); err != nil {
+			return
+		}
+		offset = 0
+	case http.StatusRequestedRangeNotSatisfiable:
+		// the part file is already complete
+		if offset == 0 {
+			return result, fmt.Errorf("failed to download %s. Status: %s", media.URL, resp.Status)
+		}
+	default:
+		return result, fmt.Errorf("failed to download %s. Status: %s", media.URL, resp.Status)
+	}
+
+	if resp.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+		written, err := io.Copy(io.MultiWriter(file, checksum), resp.Body)
+		if err != nil {
+			return result, err
+		}
+		offset += written
+	}
+	if err = file.Close(); err != nil {
+		return
+	}
+
+	result.Size = offset
+	result.SHA256 = hex.EncodeToString(checksum.Sum(nil))
+	result.Path = filepath.Join(dir, result.SHA256+mediaExtension(media.URL, resp.Header.Get("Content-Type")))
+
+	err = os.Rename(partPath, result.Path)
+	return
+}
+
+// DownloadTweet saves all media of the tweet in dir using DownloadToDir.
+// Up to Concurrency files are downloaded in parallel. Failed downloads are reported in DownloadResult.Err
+func (d *Downloader) DownloadTweet(ctx context.Context, tweet Tweet, dir string) []DownloadResult {
+	downloads := d.Resolve(tweet)
+	results := make([]DownloadResult, len(downloads))
+
+	concurrency := d.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultDownloadConcurrency
+	}
+	semaphore := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, media := range downloads {
+		wg.Add(1)
+		go func(i int, media MediaDownload) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result, err := d.DownloadToDir(ctx, media, dir)
+			result.Err = err
+			results[i] = result
+		}(i, media)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// get requests the url, starting at offset if it is greater than zero
+func (d *Downloader) get(ctx context.Context, mediaURL string, offset int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mediaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	client := d.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// restart truncates the file and resets the checksum
+func restart(file *os.File, checksum *hash.Hash) error {
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	*checksum = sha256.New()
+	return nil
+}
+
+// contentRangeStart returns the first byte position of a Content-Range header like "bytes 300-1099/1100",
+// or -1 if the header is missing or invalid
+func contentRangeStart(contentRange string) int64 {
+	var start, end int64
+	if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/", &start, &end); err != nil {
+		return -1
+	}
+	return start
+}
+
+// mediaExtension returns the file extension of the url path, falling back to the content type
+func mediaExtension(mediaURL, contentType string) string {
+	if u, err := url.Parse(mediaURL); err == nil {
+		if ext := path.Ext(u.Path); ext != "" {
+			return ext
+		}
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if extensions, _ := mime.ExtensionsByType(mediaType); len(extensions) > 0 {
+			return extensions[0]
+		}
+	}
+	return ""
+}
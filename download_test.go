@@ -0,0 +1,103 @@
+package twitter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDownloader(t *testing.T) {
+
+	content := []byte(strings.Repeat("video data ", 100))
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "video.mp4", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	tweet := Tweet{Media: []MediaItem{
+		{Key: "photo", Type: MediaTypePhoto, URL: "https://example.org/media/abc.jpg"},
+		{Key: "video", Type: MediaTypeVideo, Variants: []VideoVariant{
+			{Bitrate: 100, ContentType: ContentTypeMP4, URL: server.URL + "/low.mp4"},
+			{Bitrate: 200, ContentType: ContentTypeMP4, URL: server.URL + "/high.mp4"},
+		}},
+	}}
+
+	var downloader Downloader
+	downloads := downloader.Resolve(tweet)
+	equals(len(downloads), 2)
+	equals(downloads[0].URL, "https://example.org/media/abc.jpg?name=orig")
+	equals(downloads[1].URL, server.URL+"/high.mp4")
+
+	var buf bytes.Buffer
+	result, err := downloader.Download(context.Background(), downloads[1], &buf)
+	equals(err, nil)
+	equals(buf.String(), string(content))
+	equals(result.Size, int64(len(content)))
+	equals(result.SHA256, checksum)
+
+	// resume an interrupted download
+	dir := t.TempDir()
+	err = ioutil.WriteFile(filepath.Join(dir, "video.part"), content[:300], 0o644)
+	equals(err, nil)
+
+	ranges = nil
+	result, err = downloader.DownloadToDir(context.Background(), downloads[1], dir)
+	equals(err, nil)
+	equals(ranges[0], "bytes=300-")
+	equals(result.SHA256, checksum)
+	equals(result.Path, filepath.Join(dir, checksum+".mp4"))
+
+	saved, err := ioutil.ReadFile(result.Path)
+	equals(err, nil)
+	equals(saved, content)
+}
+
+func TestDownloadToDirWrongRange(t *testing.T) {
+
+	content := []byte(strings.Repeat("video data ", 100))
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		if r.Header.Get("Range") == "" {
+			w.Write(content)
+			return
+		}
+		// answer with the start of the file instead of the requested range
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-99/%d", len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(content[:100])
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "video.part"), content[:300], 0o644)
+	equals(err, nil)
+
+	var downloader Downloader
+	result, err := downloader.DownloadToDir(context.Background(), MediaDownload{"video", MediaTypeVideo, server.URL + "/video.mp4"}, dir)
+	equals(err, nil)
+	equals(strings.Join(ranges, ","), "bytes=300-,")
+	equals(result.Size, int64(len(content)))
+	equals(result.SHA256, checksum)
+
+	saved, err := ioutil.ReadFile(result.Path)
+	equals(err, nil)
+	equals(saved, content)
+}
@@ -0,0 +1,64 @@
+package twitter
+
+// editControls are the edit controls of a tweet as given by the api
+type editControls struct {
+	EditableUntil  string `json:"editable_until"`
+	EditsRemaining int    `json:"edits_remaining"`
+	IsEditEligible bool   `json:"is_edit_eligible"`
+}
+
+// EditControls describe whether and until when a tweet can be edited
+type EditControls struct {
+	EditableUntil  string `json:"editableUntil"`
+	EditsRemaining int    `json:"editsRemaining"`
+	IsEditEligible bool   `json:"isEditEligible"`
+}
+
+// convertToEditControls converts twitters edit controls, which are only set if they were requested
+func convertToEditControls(c *editControls) *EditControls {
+	if c == nil {
+		return nil
+	}
+	return &EditControls{
+		EditableUntil:  c.EditableUntil,
+		EditsRemaining: c.EditsRemaining,
+		IsEditEligible: c.IsEditEligible,
+	}
+}
+
+// IsEdited reports whether there are older versions of the tweet.
+// This requires the edit_history_tweet_ids field, which is part of DefaultFields
+func (t Tweet) IsEdited() bool {
+	return len(t.EditHistoryTweetIDs) > 1
+}
+
+// OriginalID returns the ID of the first version of the tweet
+func (t Tweet) OriginalID() string {
+	if len(t.EditHistoryTweetIDs) == 0 {
+		return t.ID
+	}
+	return t.EditHistoryTweetIDs[0]
+}
+
+// LatestVersions collapses the versions of edited tweets so that only the latest one is kept.
+// Versions are matched by OriginalID and the latest is the one with the longest edit history,
+// which contains the IDs of all previous versions.
+// The order of the tweets is kept, with each latest version taking the place of the first version seen
+func LatestVersions(tweets []Tweet) []Tweet {
+	positions := make(map[string]int)
+	var latest []Tweet
+
+	for _, tweet := range tweets {
+		id := tweet.OriginalID()
+		position, seen := positions[id]
+		if !seen {
+			positions[id] = len(latest)
+			latest = append(latest, tweet)
+			continue
+		}
+		if len(tweet.EditHistoryTweetIDs) > len(latest[position].EditHistoryTweetIDs) {
+			latest[position] = tweet
+		}
+	}
+	return latest
+}
@@ -0,0 +1,49 @@
+package twitter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLatestVersions(t *testing.T) {
+
+	tweets := []Tweet{
+		{ID: "2", Text: "edited", EditHistoryTweetIDs: []string{"1", "2"}},
+		{ID: "10", Text: "unrelated", EditHistoryTweetIDs: []string{"10"}},
+		{ID: "1", Text: "original", EditHistoryTweetIDs: []string{"1"}},
+		{ID: "3", Text: "edited again", EditHistoryTweetIDs: []string{"1", "2", "3"}},
+		{ID: "20", Text: "no history requested"},
+	}
+
+	latest := LatestVersions(tweets)
+	equals(len(latest), 3)
+	equals(latest[0].ID, "3")
+	equals(latest[0].IsEdited(), true)
+	equals(len(latest[0].EditHistoryTweetIDs), 3)
+	equals(latest[1].ID, "10")
+	equals(latest[1].IsEdited(), false)
+	equals(latest[2].OriginalID(), "20")
+}
+
+func TestConvertToTweetEditControls(t *testing.T) {
+
+	var response tweetResponse
+	err := json.Unmarshal([]byte(`{"data": {"id": "1", "edit_history_tweet_ids": ["1"],
+		"edit_controls": {"editable_until": "2022-10-10T10:30:00.000Z", "edits_remaining": 5, "is_edit_eligible": true}}}`), &response)
+	equals(err, nil)
+
+	tweet := convertToTweet(response.Tweet, response.Includes, nil)
+	equals(tweet.EditControls.EditableUntil, "2022-10-10T10:30:00.000Z")
+	equals(tweet.EditControls.EditsRemaining, 5)
+	equals(tweet.EditControls.IsEditEligible, true)
+
+	// like the other fields of Tweet, the edit controls are serialized in camelCase
+	data, err := json.Marshal(tweet)
+	equals(err, nil)
+	var fields map[string]json.RawMessage
+	equals(json.Unmarshal(data, &fields), nil)
+	var controls map[string]interface{}
+	equals(json.Unmarshal(fields["editControls"], &controls), nil)
+	equals(controls["editableUntil"], "2022-10-10T10:30:00.000Z")
+	equals(controls["editsRemaining"], float64(5))
+}
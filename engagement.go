@@ -0,0 +1,166 @@
+package twitter
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PageOptions paginate requests returning users. The zero value requests the first page with twitters defaults
+type PageOptions struct {
+	MaxResults      int    // number of users per page
+	PaginationToken string // NextToken of the previous UserPage
+}
+
+// UserPage is one page of users
+type UserPage struct {
+	Users     []User
+	NextToken string // pass as PageOptions.PaginationToken to get the next page, empty on the last page
+}
+
+// Me calls the /2/users/me endpoint and returns the authenticated user.
+// It requires user context authentication, see NewWithAuth
+func (tw *Client) Me() (User, error) {
+	return tw.getProfile(fmt.Sprintf("%s/users/me?%s", apiRoot, tw.userFieldsQuery()))
+}
+
+// authenticatedUserID returns the ID of the authenticated user, which is looked up once.
+// It does not take the Client lock, so it can be used while a stream subscriber is being served
+func (tw *Client) authenticatedUserID() (string, error) {
+	tw.myIDLock.Lock()
+	defer tw.myIDLock.Unlock()
+	if tw.myID != "" {
+		return tw.myID, nil
+	}
+
+	me, err := tw.Me()
+	if err != nil {
+		return "", err
+	}
+	tw.myID = me.ID
+	return me.ID, nil
+}
+
+// Like likes the tweet as the authenticated user
+func (tw *Client) Like(tweetID string) error {
+	return tw.userAction(http.MethodPost, "likes", "tweet_id", tweetID, "liked", true)
+}
+
+// Unlike removes the like of the authenticated user from the tweet
+func (tw *Client) Unlike(tweetID string) error {
+	return tw.userAction(http.MethodDelete, "likes", "tweet_id", tweetID, "liked", false)
+}
+
+// Retweet retweets the tweet as the authenticated user
+func (tw *Client) Retweet(tweetID string) error {
+	return tw.userAction(http.MethodPost, "retweets", "tweet_id", tweetID, "retweeted", true)
+}
+
+// Unretweet removes the retweet of the tweet by the authenticated user
+func (tw *Client) Unretweet(tweetID string) error {
+	return tw.userAction(http.MethodDelete, "retweets", "tweet_id", tweetID, "retweeted", false)
+}
+
+// Bookmark adds the tweet to the bookmarks of the authenticated user
+func (tw *Client) Bookmark(tweetID string) error {
+	return tw.userAction(http.MethodPost, "bookmarks", "tweet_id", tweetID, "bookmarked", true)
+}
+
+// RemoveBookmark removes the tweet from the bookmarks of the authenticated user
+func (tw *Client) RemoveBookmark(tweetID string) error {
+	return tw.userAction(http.MethodDelete, "bookmarks", "tweet_id", tweetID, "bookmarked", false)
+}
+
+// userAction calls /2/users/:id/<collection> for the authenticated user, either POSTing the target ID
+// as targetField, e.g. {"tweet_id": "1"}, or DELETEing it, and checks that the response reports
+// the expected state, e.g. {"liked": true}
+func (tw *Client) userAction(method, collection, targetField, targetID, stateKey string, expected bool) error {
+	userID, err := tw.authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	uri := fmt.Sprintf("%s/users/%s/%s", apiRoot, userID, collection)
+	var body interface{}
+	if method == http.MethodDelete {
+		uri += "/" + url.PathEscape(targetID)
+	} else {
+		body = map[string]string{targetField: targetID}
+	}
+
+	return tw.sendAction(method, uri, body, stateKey, expected)
+}
+
+// sendAction sends a request changing a resource and checks that the response reports the expected state,
+// e.g. {"data": {"liked": true}}
+func (tw *Client) sendAction(method, uri string, body interface{}, stateKey string, expected bool) error {
+	var response struct {
+		Data map[string]bool `json:"data"`
+	}
+	err := tw.sendJSON(method, uri, body, &response)
+	if err != nil {
+		return err
+	}
+	if response.Data[stateKey] != expected {
+		return fmt.Errorf("%s %s failed: %s is %t", method, uri, stateKey, response.Data[stateKey])
+	}
+	return nil
+}
+
+// GetLikingUsers returns the users who liked the tweet
+func (tw *Client) GetLikingUsers(tweetID string, opts PageOptions) (UserPage, error) {
+	return tw.getUserPage(fmt.Sprintf("%s/tweets/%s/liking_users", apiRoot, tweetID), opts)
+}
+
+// GetRetweetedBy returns the users who retweeted the tweet
+func (tw *Client) GetRetweetedBy(tweetID string, opts PageOptions) (UserPage, error) {
+	return tw.getUserPage(fmt.Sprintf("%s/tweets/%s/retweeted_by", apiRoot, tweetID), opts)
+}
+
+// GetQuoteTweets returns the tweets quoting the tweet
+func (tw *Client) GetQuoteTweets(tweetID string, opts TimelineOptions) (TimelinePage, error) {
+	return tw.getTimeline(fmt.Sprintf("%s/tweets/%s/quote_tweets", apiRoot, tweetID), opts)
+}
+
+// GetLikedTweets returns the tweets liked by the user
+func (tw *Client) GetLikedTweets(userID string, opts TimelineOptions) (TimelinePage, error) {
+	return tw.getTimeline(fmt.Sprintf("%s/users/%s/liked_tweets", apiRoot, userID), opts)
+}
+
+// GetBookmarks returns the bookmarks of the authenticated user
+func (tw *Client) GetBookmarks(opts TimelineOptions) (TimelinePage, error) {
+	userID, err := tw.authenticatedUserID()
+	if err != nil {
+		return TimelinePage{}, err
+	}
+	return tw.getTimeline(fmt.Sprintf("%s/users/%s/bookmarks", apiRoot, userID), opts)
+}
+
+// getUserPage requests a page of users from endpoint
+func (tw *Client) getUserPage(endpoint string, opts PageOptions) (page UserPage, err error) {
+	uri := withQuery(endpoint, opts.query(), tw.userFieldsQuery())
+
+	var response usersResponse
+	err = tw.getJSON(uri, &response)
+	if err != nil {
+		return
+	}
+
+	return UserPage{
+		Users:     convertToUsers(response.Users),
+		NextToken: response.Meta.NextToken,
+	}, nil
+}
+
+// query encodes the options as url query parameters
+func (opts PageOptions) query() string {
+	params := url.Values{}
+	if opts.MaxResults > 0 {
+		params.Set("max_results", strconv.Itoa(opts.MaxResults))
+	}
+	if opts.PaginationToken != "" {
+		params.Set("pagination_token", opts.PaginationToken)
+	}
+	return params.Encode()
+}
@@ -0,0 +1,104 @@
+package twitter
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEngagementActions(t *testing.T) {
+
+	var meRequests int
+	var actions []string
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/2/users/me" {
+			meRequests++
+			w.Write([]byte(`{"data": {"id": "42", "username": "gopher"}}`))
+			return
+		}
+		actions = append(actions, r.Method+" "+r.URL.Path)
+
+		switch r.Method {
+		case http.MethodPost:
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			equals(body["tweet_id"], "7")
+			w.Write([]byte(`{"data": {"liked": true, "retweeted": true, "bookmarked": true}}`))
+		case http.MethodDelete:
+			w.Write([]byte(`{"data": {"liked": false, "retweeted": false, "bookmarked": false}}`))
+		}
+	})
+
+	equals(tw.Like("7"), nil)
+	equals(tw.Unlike("7"), nil)
+	equals(tw.Retweet("7"), nil)
+	equals(tw.Unretweet("7"), nil)
+	equals(tw.Bookmark("7"), nil)
+	equals(tw.RemoveBookmark("7"), nil)
+
+	equals(meRequests, 1)
+	equals(actions[0], "POST /2/users/42/likes")
+	equals(actions[1], "DELETE /2/users/42/likes/7")
+	equals(actions[3], "DELETE /2/users/42/retweets/7")
+	equals(actions[5], "DELETE /2/users/42/bookmarks/7")
+}
+
+func TestLikeFromStream(t *testing.T) {
+
+	var mutex sync.Mutex
+	var liked []string
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2/tweets/search/stream":
+			writeStreamedTweets(w, "r1", "1", "2", "3")
+		case "/2/users/me":
+			w.Write([]byte(`{"data": {"id": "42", "username": "gopher"}}`))
+		case "/2/users/42/likes":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			mutex.Lock()
+			liked = append(liked, body["tweet_id"])
+			mutex.Unlock()
+			w.Write([]byte(`{"data": {"liked": true}}`))
+		}
+	})
+
+	sub := tw.SubscribeStream(StreamRule{ID: "r1"})
+	tw.StartStream()
+
+	// the stream keeps forwarding tweets while the subscriber likes the previous one
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 3; i++ {
+			tweet := <-sub.Tweets
+			equals(tw.Like(tweet.ID), nil)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Like did not return while the stream was forwarding tweets")
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	equals(strings.Join(liked, ","), "1,2,3")
+}
+
+func TestGetLikingUsers(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.URL.Path, "/2/tweets/7/liking_users")
+		equals(r.URL.Query().Get("pagination_token"), "next")
+		w.Write([]byte(`{"data": [{"id": "1", "username": "one"}], "meta": {"result_count": 1}}`))
+	})
+
+	page, err := tw.GetLikingUsers("7", PageOptions{PaginationToken: "next"})
+	equals(err, nil)
+	equals(page.Users[0].Handle, "one")
+	equals(page.NextToken, "")
+}
@@ -0,0 +1,81 @@
+package twitter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Problem types twitter uses in APIError.Type
+const (
+	problemNotFound      = "https://api.twitter.com/2/problems/resource-not-found"
+	problemNotAuthorized = "https://api.twitter.com/2/problems/not-authorized-for-resource"
+)
+
+// ErrNotFound matches APIErrors for resources that do not exist (anymore), e.g. deleted tweets
+var ErrNotFound = fmt.Errorf("resource not found")
+
+// ErrNotAuthorized matches APIErrors for resources the client may not see, e.g. tweets of protected users
+var ErrNotAuthorized = fmt.Errorf("not authorized for resource")
+
+// ErrRateLimited matches RateLimitErrors
+var ErrRateLimited = fmt.Errorf("rate limit exceeded")
+
+// RateLimitError is returned when twitter rejects a request because the rate limit of the endpoint is exhausted
+type RateLimitError struct {
+	Reset time.Time // time at which new requests are accepted again
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded, resets at %s", e.Reset.Format(time.RFC3339))
+}
+
+// Is makes errors.Is match ErrRateLimited
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// Wait blocks until the rate limit resets or ctx is done
+func (e *RateLimitError) Wait(ctx context.Context) error {
+	return sleep(ctx, time.Until(e.Reset))
+}
+
+// rateLimitError builds a RateLimitError from the x-rate-limit-reset header of the response.
+// If the header is missing, a reset in 15 minutes is assumed, which is the length of twitters rate limit windows
+func rateLimitError(response *http.Response) *RateLimitError {
+	reset, err := strconv.ParseInt(response.Header.Get("x-rate-limit-reset"), 10, 64)
+	if err != nil {
+		return &RateLimitError{Reset: time.Now().Add(15 * time.Minute)}
+	}
+	return &RateLimitError{Reset: time.Unix(reset, 0)}
+}
+
+// APIError is an error twitter reports for a single resource of a request,
+// e.g. one ID of a batch lookup.
+// Use errors.Is with ErrNotFound or ErrNotAuthorized to check the kind of error
+type APIError struct {
+	Value        string `json:"value"`
+	Detail       string `json:"detail"`
+	Title        string `json:"title"`
+	ResourceType string `json:"resource_type"`
+	Parameter    string `json:"parameter"`
+	ResourceID   string `json:"resource_id"`
+	Type         string `json:"type"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Title, e.Detail)
+}
+
+// Is makes errors.Is match ErrNotFound and ErrNotAuthorized
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Type == problemNotFound
+	case ErrNotAuthorized:
+		return e.Type == problemNotAuthorized
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package twitter
+
+import "strings"
+
+// Fields selects which expansions and object fields twitter includes in tweet responses.
+// Empty lists are left out of the request, in which case twitter only returns the default fields
+// of that object. See https://developer.twitter.com/en/docs/twitter-api/fields for all options
+type Fields struct {
+	Expansions  []string
+	TweetFields []string
+	UserFields  []string
+	MediaFields []string
+	PollFields  []string
+	PlaceFields []string
+}
+
+// DefaultFields returns the Fields used when neither the Client nor the request specify any.
+// They contain everything needed to fill the fields of Tweet
+func DefaultFields() Fields {
+	return Fields{
+		Expansions: []string{"author_id", "attachments.media_keys", "attachments.poll_ids", "geo.place_id"},
+		TweetFields: []string{"author_id", "created_at", "text", "public_metrics", "possibly_sensitive", "geo",
+			"source", "reply_settings", "conversation_id", "edit_history_tweet_ids", "edit_controls", "lang", "context_annotations"},
+		UserFields: []string{"profile_image_url", "verified"},
+		MediaFields: []string{"type", "url", "media_key", "preview_image_url", "width", "height", "duration_ms",
+			"alt_text", "public_metrics", "variants"},
+		PollFields:  []string{"duration_minutes", "end_datetime", "voting_status", "options"},
+		PlaceFields: []string{"full_name", "name", "country", "country_code", "place_type", "geo"},
+	}
+}
+
+// Query encodes the selection as url query parameters
+func (f Fields) Query() string {
+	params := []struct {
+		name   string
+		values []string
+	}{
+		{"expansions", f.Expansions},
+		{"tweet.fields", f.TweetFields},
+		{"user.fields", f.UserFields},
+		{"media.fields", f.MediaFields},
+		{"poll.fields", f.PollFields},
+		{"place.fields", f.PlaceFields},
+	}
+
+	query := strings.Builder{}
+	for _, param := range params {
+		if len(param.values) == 0 {
+			continue
+		}
+		if query.Len() > 0 {
+			query.WriteString("&")
+		}
+		query.WriteString(param.name)
+		query.WriteString("=")
+		query.WriteString(strings.Join(param.values, ","))
+	}
+	return query.String()
+}
+
+// fields returns the Fields to request: the given ones, the Clients default or DefaultFields
+func (tw *Client) fields(fields *Fields) Fields {
+	if fields != nil {
+		return *fields
+	}
+	if tw.Fields != nil {
+		return *tw.Fields
+	}
+	return DefaultFields()
+}
+
+// userFieldsQuery returns the user.fields parameter for user lookups: the Clients UserFields
+// if there are any, otherwise every field of User. Fields.UserFields only apply to tweet authors
+func (tw *Client) userFieldsQuery() string {
+	if len(tw.UserFields) > 0 {
+		return "user.fields=" + strings.Join(tw.UserFields, ",")
+	}
+	return userFields
+}
@@ -0,0 +1,40 @@
+package twitter
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFieldsQuery(t *testing.T) {
+	fields := Fields{
+		Expansions:  []string{"author_id"},
+		TweetFields: []string{"lang", "source"},
+	}
+	equals(fields.Query(), "expansions=author_id&tweet.fields=lang,source")
+	equals(Fields{}.Query(), "")
+}
+
+func TestSearchRecentWithFields(t *testing.T) {
+
+	var query string
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("tweet.fields")
+		w.Write([]byte(`{"data": [{"id": "1", "text": "hi", "source": "Twitter Web App", "reply_settings": "following"}]}`))
+	})
+
+	tweets, err := tw.SearchRecent("golang")
+	equals(err, nil)
+	equals(query, "author_id,created_at,text,public_metrics,possibly_sensitive,geo,source,reply_settings,conversation_id,"+
+		"edit_history_tweet_ids,edit_controls,lang,context_annotations")
+	equals(tweets[0].Source, "Twitter Web App")
+	equals(tweets[0].ReplySettings, "following")
+
+	tw.Fields = &Fields{TweetFields: []string{"source"}}
+	_, err = tw.SearchRecent("golang")
+	equals(err, nil)
+	equals(query, "source")
+
+	_, err = tw.SearchRecentWithFields(&Fields{TweetFields: []string{"reply_settings"}}, "golang")
+	equals(err, nil)
+	equals(query, "reply_settings")
+}
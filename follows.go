@@ -0,0 +1,159 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Relations that can be traversed using FollowCursor
+const (
+	Followers = "followers"
+	Following = "following"
+)
+
+// maxFollowResults is the largest page size the follows endpoints accept
+const maxFollowResults = 1000
+
+// FollowCursor is the position within a followers or following list.
+// It can be serialised, e.g. as json, to resume a crawl later
+type FollowCursor struct {
+	UserID     string `json:"userID"`
+	Relation   string `json:"relation"`             // Followers or Following
+	MaxResults int    `json:"maxResults,omitempty"` // page size, defaults to 1000
+	NextToken  string `json:"nextToken,omitempty"`
+	Done       bool   `json:"done"`
+}
+
+// FollowIterator pages through the followers or following list of a user
+type FollowIterator struct {
+	tw       *Client
+	cursor   FollowCursor
+	profiles []User
+	err      error
+}
+
+// Followers returns an iterator over the users following userID
+func (tw *Client) Followers(userID string) *FollowIterator {
+	return tw.Follows(FollowCursor{UserID: userID, Relation: Followers})
+}
+
+// Following returns an iterator over the users followed by userID
+func (tw *Client) Following(userID string) *FollowIterator {
+	return tw.Follows(FollowCursor{UserID: userID, Relation: Following})
+}
+
+// Follows returns an iterator that starts at the cursor, e.g. one saved by a previous crawl
+func (tw *Client) Follows(cursor FollowCursor) *FollowIterator {
+	return &FollowIterator{tw: tw, cursor: cursor}
+}
+
+// Next fetches the next page and reports whether it succeeded.
+// It returns false when the list is exhausted or a request failed, see Err
+func (it *FollowIterator) Next() bool {
+	if it.cursor.Done || it.err != nil {
+		return false
+	}
+
+	maxResults := it.cursor.MaxResults
+	if maxResults <= 0 {
+		maxResults = maxFollowResults
+	}
+	params := url.Values{}
+	params.Set("max_results", strconv.Itoa(maxResults))
+	if it.cursor.NextToken != "" {
+		params.Set("pagination_token", it.cursor.NextToken)
+	}
+	uri := fmt.Sprintf("%s/users/%s/%s?%s&%s", apiRoot, it.cursor.UserID, it.cursor.Relation, params.Encode(), it.tw.userFieldsQuery())
+
+	var response usersResponse
+	it.err = it.tw.getJSON(uri, &response)
+	if it.err != nil {
+		return false
+	}
+
+	it.profiles = convertToUsers(response.Users)
+	it.cursor.NextToken = response.Meta.NextToken
+	it.cursor.Done = response.Meta.NextToken == ""
+	return true
+}
+
+// Profiles returns the page fetched by the last call to Next
+func (it *FollowIterator) Profiles() []User {
+	return it.profiles
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *FollowIterator) Err() error {
+	return it.err
+}
+
+// Cursor returns the position after the current page. Passing it to Follows continues with the next page
+func (it *FollowIterator) Cursor() FollowCursor {
+	return it.cursor
+}
+
+// CrawlFollows fetches every page starting at cursor and calls checkpoint with the profiles of each page
+// and the cursor pointing to the next one. Persisting both allows resuming an interrupted crawl.
+// When the rate limit is exhausted, CrawlFollows waits for it to reset. It stops when the list is complete,
+// ctx is done or checkpoint returns an error
+func (tw *Client) CrawlFollows(ctx context.Context, cursor FollowCursor, checkpoint func(profiles []User, cursor FollowCursor) error) error {
+	for {
+		it := tw.Follows(cursor)
+		for it.Next() {
+			cursor = it.Cursor()
+			if err := checkpoint(it.Profiles(), cursor); err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		var rateLimitErr *RateLimitError
+		if !errors.As(it.Err(), &rateLimitErr) {
+			return it.Err()
+		}
+		if err := rateLimitErr.Wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// CollectFollows returns the complete followers or following list of a user using CrawlFollows
+func (tw *Client) CollectFollows(ctx context.Context, userID, relation string) ([]User, error) {
+	var all []User
+	err := tw.CrawlFollows(ctx, FollowCursor{UserID: userID, Relation: relation}, func(profiles []User, _ FollowCursor) error {
+		all = append(all, profiles...)
+		return nil
+	})
+	return all, err
+}
+
+// MutualFollows returns the users that appear in the relation lists of both users.
+// With Followers these are the users following both, with Following the users both follow
+func (tw *Client) MutualFollows(ctx context.Context, userA, userB, relation string) ([]User, error) {
+	listA, err := tw.CollectFollows(ctx, userA, relation)
+	if err != nil {
+		return nil, err
+	}
+	listB, err := tw.CollectFollows(ctx, userB, relation)
+	if err != nil {
+		return nil, err
+	}
+
+	inA := make(map[string]bool, len(listA))
+	for _, profile := range listA {
+		inA[profile.ID] = true
+	}
+
+	var mutual []User
+	for _, profile := range listB {
+		if inA[profile.ID] {
+			mutual = append(mutual, profile)
+		}
+	}
+	return mutual, nil
+}
@@ -0,0 +1,74 @@
+package twitter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCrawlFollows(t *testing.T) {
+
+	var requests int
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		equals(r.URL.Path, "/2/users/42/followers")
+
+		// the second request hits the rate limit, which resets immediately
+		if requests == 2 {
+			w.Header().Set("x-rate-limit-reset", fmt.Sprint(time.Now().Unix()))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		switch r.URL.Query().Get("pagination_token") {
+		case "":
+			w.Write([]byte(`{"data": [{"id": "1"}, {"id": "2"}], "meta": {"next_token": "b"}}`))
+		case "b":
+			w.Write([]byte(`{"data": [{"id": "3"}], "meta": {}}`))
+		}
+	})
+
+	var ids []string
+	var cursors []FollowCursor
+	err := tw.CrawlFollows(context.Background(), FollowCursor{UserID: "42", Relation: Followers},
+		func(profiles []User, cursor FollowCursor) error {
+			for _, profile := range profiles {
+				ids = append(ids, profile.ID)
+			}
+			cursors = append(cursors, cursor)
+			return nil
+		})
+	equals(err, nil)
+	equals(requests, 3)
+	equals(strings.Join(ids, ","), "1,2,3")
+	equals(cursors[0].NextToken, "b")
+	equals(cursors[1].Done, true)
+
+	// a finished cursor does not request anything
+	requests = 0
+	it := tw.Follows(cursors[1])
+	equals(it.Next(), false)
+	equals(it.Err(), nil)
+	equals(requests, 0)
+}
+
+func TestMutualFollows(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2/users/a/following":
+			w.Write([]byte(`{"data": [{"id": "1"}, {"id": "2"}, {"id": "3"}], "meta": {}}`))
+		case "/2/users/b/following":
+			w.Write([]byte(`{"data": [{"id": "3"}, {"id": "4"}, {"id": "1"}], "meta": {}}`))
+		}
+	})
+
+	mutual, err := tw.MutualFollows(context.Background(), "a", "b", Following)
+	equals(err, nil)
+	equals(len(mutual), 2)
+	equals(mutual[0].ID, "3")
+	equals(mutual[1].ID, "1")
+}
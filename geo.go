@@ -0,0 +1,108 @@
+package twitter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// geo is the location information of a tweet as given by the api
+type geo struct {
+	PlaceID     string `json:"place_id"`
+	Coordinates *struct {
+		Type        string    `json:"type"`
+		Coordinates []float64 `json:"coordinates"` // longitude, latitude
+	} `json:"coordinates"`
+}
+
+// place is a place as given by the api
+type place struct {
+	ID          string `json:"id"`
+	FullName    string `json:"full_name"`
+	Name        string `json:"name"`
+	Country     string `json:"country"`
+	CountryCode string `json:"country_code"`
+	PlaceType   string `json:"place_type"`
+	Geo         struct {
+		BoundingBox []float64 `json:"bbox"` // west, south, east, north
+	} `json:"geo"`
+}
+
+// Place represents a named location a tweet is associated with
+type Place struct {
+	ID          string       `json:"id"`
+	FullName    string       `json:"fullName"`
+	Name        string       `json:"name"`
+	Country     string       `json:"country"`
+	CountryCode string       `json:"countryCode"`
+	PlaceType   string       `json:"placeType"` // e.g. city, admin, country or poi
+	BoundingBox *BoundingBox `json:"boundingBox,omitempty"`
+}
+
+// BoundingBox is a rectangular area given by longitudes and latitudes
+type BoundingBox struct {
+	West  float64 `json:"west"`
+	South float64 `json:"south"`
+	East  float64 `json:"east"`
+	North float64 `json:"north"`
+}
+
+// Coordinates is an exact point on the map
+type Coordinates struct {
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+}
+
+// convertToPlace converts twitters place object into a Place
+func convertToPlace(p place) Place {
+	converted := Place{
+		ID:          p.ID,
+		FullName:    p.FullName,
+		Name:        p.Name,
+		Country:     p.Country,
+		CountryCode: p.CountryCode,
+		PlaceType:   p.PlaceType,
+	}
+	if bbox := p.Geo.BoundingBox; len(bbox) == 4 {
+		converted.BoundingBox = &BoundingBox{
+			West:  bbox[0],
+			South: bbox[1],
+			East:  bbox[2],
+			North: bbox[3],
+		}
+	}
+	return converted
+}
+
+// The following functions build geo operators that can be passed to CreateStreamRule and SearchRecent
+// like ImageFilter
+
+// PlaceFilter matches tweets tagged with the place given by its name or ID
+func PlaceFilter(place string) string {
+	if strings.ContainsAny(place, " \t") {
+		place = strconv.Quote(place)
+	}
+	return "place:" + place
+}
+
+// PlaceCountryFilter matches tweets tagged with a place in the country given by its ISO alpha-2 code
+func PlaceCountryFilter(countryCode string) string {
+	return "place_country:" + countryCode
+}
+
+// PointRadiusFilter matches tweets located within radius of the point.
+// The radius needs a unit, e.g. "10km" or "5mi", and may not exceed 25 miles
+func PointRadiusFilter(point Coordinates, radius string) string {
+	return fmt.Sprintf("point_radius:[%s %s %s]", formatDegrees(point.Longitude), formatDegrees(point.Latitude), radius)
+}
+
+// BoundingBoxFilter matches tweets located within the box. Each side may not exceed 25 miles
+func BoundingBoxFilter(box BoundingBox) string {
+	return fmt.Sprintf("bounding_box:[%s %s %s %s]",
+		formatDegrees(box.West), formatDegrees(box.South), formatDegrees(box.East), formatDegrees(box.North))
+}
+
+// formatDegrees formats a longitude or latitude without trailing zeros
+func formatDegrees(degrees float64) string {
+	return strconv.FormatFloat(degrees, 'f', -1, 64)
+}
@@ -0,0 +1,47 @@
+package twitter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConvertToTweetGeo(t *testing.T) {
+
+	var response tweetResponse
+	err := json.Unmarshal([]byte(`{
+		"data": {"id": "1", "text": "hello", "geo": {
+			"place_id": "01a9a39529b27f36",
+			"coordinates": {"type": "Point", "coordinates": [-73.99, 40.75]}
+		}},
+		"includes": {"places": [{
+			"id": "01a9a39529b27f36",
+			"full_name": "Manhattan, NY",
+			"name": "Manhattan",
+			"country": "United States",
+			"country_code": "US",
+			"place_type": "city",
+			"geo": {"type": "Feature", "bbox": [-74.026675, 40.683935, -73.910408, 40.877483], "properties": {}}
+		}]}
+	}`), &response)
+	equals(err, nil)
+
+	niceTweet := convertToTweet(response.Tweet, response.Includes, nil)
+	equals(niceTweet.Place.FullName, "Manhattan, NY")
+	equals(niceTweet.Place.CountryCode, "US")
+	equals(niceTweet.Place.PlaceType, "city")
+	equals(*niceTweet.Place.BoundingBox, BoundingBox{-74.026675, 40.683935, -73.910408, 40.877483})
+	equals(*niceTweet.Coordinates, Coordinates{Longitude: -73.99, Latitude: 40.75})
+
+	niceTweet = convertToTweet(tweet{ID: "2", Text: "no location"}, includes{}, nil)
+	equals(niceTweet.Place == nil, true)
+	equals(niceTweet.Coordinates == nil, true)
+}
+
+func TestGeoFilters(t *testing.T) {
+	equals(PlaceFilter("new york city"), `place:"new york city"`)
+	equals(PlaceFilter("fd70c22040963ac7"), "place:fd70c22040963ac7")
+	equals(PlaceCountryFilter("US"), "place_country:US")
+	equals(PointRadiusFilter(Coordinates{Longitude: 2.355128, Latitude: 48.861118}, "16km"), "point_radius:[2.355128 48.861118 16km]")
+	equals(BoundingBoxFilter(BoundingBox{-105.301758, 39.964069, -105.126047, 40.09455}),
+		"bounding_box:[-105.301758 39.964069 -105.126047 40.09455]")
+}
@@ -0,0 +1,116 @@
+package twitter
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const listFields = "list.fields=created_at,description,private,follower_count,member_count,owner_id"
+
+// List is a curated group of twitter users
+type List struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Description   string `json:"description,omitempty"`
+	Private       bool   `json:"private"`
+	FollowerCount int    `json:"follower_count"`
+	MemberCount   int    `json:"member_count"`
+	OwnerID       string `json:"owner_id,omitempty"`
+	CreatedAt     string `json:"created_at,omitempty"`
+}
+
+// ListPage is one page of lists
+type ListPage struct {
+	Lists     []List
+	NextToken string // pass as PageOptions.PaginationToken to get the next page, empty on the last page
+}
+
+// ListUpdate describes changes to a list. Empty fields are left unchanged
+type ListUpdate struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Private     *bool  `json:"private,omitempty"`
+}
+
+// GetList returns the list with the given ID
+func (tw *Client) GetList(listID string) (list List, err error) {
+	var response struct {
+		List   List       `json:"data"`
+		Errors []APIError `json:"errors"`
+	}
+	err = tw.getJSON(fmt.Sprintf("%s/lists/%s?%s", apiRoot, listID, listFields), &response)
+	if err != nil {
+		return
+	}
+	if response.List.ID == "" && len(response.Errors) > 0 {
+		return list, &response.Errors[0]
+	}
+	return response.List, nil
+}
+
+// GetOwnedLists returns the lists owned by the user
+func (tw *Client) GetOwnedLists(userID string, opts PageOptions) (page ListPage, err error) {
+	var response struct {
+		Lists []List `json:"data"`
+		Meta  meta   `json:"meta"`
+	}
+	uri := withQuery(fmt.Sprintf("%s/users/%s/owned_lists", apiRoot, userID), opts.query(), listFields)
+	err = tw.getJSON(uri, &response)
+	if err != nil {
+		return
+	}
+	return ListPage{Lists: response.Lists, NextToken: response.Meta.NextToken}, nil
+}
+
+// GetListTweets returns the tweets of the lists members, newest first.
+// Only MaxResults, PaginationToken and Fields of opts are supported
+func (tw *Client) GetListTweets(listID string, opts TimelineOptions) (TimelinePage, error) {
+	return tw.getTimeline(fmt.Sprintf("%s/lists/%s/tweets", apiRoot, listID), opts)
+}
+
+// GetListMembers returns the users who are members of the list
+func (tw *Client) GetListMembers(listID string, opts PageOptions) (UserPage, error) {
+	return tw.getUserPage(fmt.Sprintf("%s/lists/%s/members", apiRoot, listID), opts)
+}
+
+// GetListFollowers returns the users who follow the list
+func (tw *Client) GetListFollowers(listID string, opts PageOptions) (UserPage, error) {
+	return tw.getUserPage(fmt.Sprintf("%s/lists/%s/followers", apiRoot, listID), opts)
+}
+
+// CreateList creates a list owned by the authenticated user. Only ID and Name of the returned List are set
+func (tw *Client) CreateList(name, description string, private bool) (list List, err error) {
+	body := struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Private     bool   `json:"private"`
+	}{name, description, private}
+
+	var response struct {
+		List List `json:"data"`
+	}
+	err = tw.sendJSON(http.MethodPost, fmt.Sprintf("%s/lists", apiRoot), &body, &response)
+	return response.List, err
+}
+
+// UpdateList changes the name, description or visibility of a list owned by the authenticated user
+func (tw *Client) UpdateList(listID string, update ListUpdate) error {
+	return tw.sendAction(http.MethodPut, fmt.Sprintf("%s/lists/%s", apiRoot, listID), &update, "updated", true)
+}
+
+// DeleteList deletes a list owned by the authenticated user
+func (tw *Client) DeleteList(listID string) error {
+	return tw.sendAction(http.MethodDelete, fmt.Sprintf("%s/lists/%s", apiRoot, listID), nil, "deleted", true)
+}
+
+// AddListMember adds the user to a list owned by the authenticated user
+func (tw *Client) AddListMember(listID, userID string) error {
+	body := map[string]string{"user_id": userID}
+	return tw.sendAction(http.MethodPost, fmt.Sprintf("%s/lists/%s/members", apiRoot, listID), body, "is_member", true)
+}
+
+// RemoveListMember removes the user from a list owned by the authenticated user
+func (tw *Client) RemoveListMember(listID, userID string) error {
+	uri := fmt.Sprintf("%s/lists/%s/members/%s", apiRoot, listID, userID)
+	return tw.sendAction(http.MethodDelete, uri, nil, "is_member", false)
+}
@@ -0,0 +1,69 @@
+package twitter
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestListManagement(t *testing.T) {
+
+	var requests []string
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /2/lists":
+			equals(body["name"], "gophers")
+			equals(body["private"], true)
+			w.Write([]byte(`{"data": {"id": "5", "name": "gophers"}}`))
+		case "PUT /2/lists/5":
+			equals(body["private"], false)
+			equals(body["name"], nil)
+			w.Write([]byte(`{"data": {"updated": true}}`))
+		case "POST /2/lists/5/members":
+			equals(body["user_id"], "42")
+			w.Write([]byte(`{"data": {"is_member": true}}`))
+		case "DELETE /2/lists/5/members/42":
+			w.Write([]byte(`{"data": {"is_member": false}}`))
+		case "DELETE /2/lists/5":
+			w.Write([]byte(`{"data": {"deleted": false}}`))
+		}
+	})
+
+	list, err := tw.CreateList("gophers", "", true)
+	equals(err, nil)
+	equals(list.ID, "5")
+
+	public := false
+	equals(tw.UpdateList("5", ListUpdate{Private: &public}), nil)
+	equals(tw.AddListMember("5", "42"), nil)
+	equals(tw.RemoveListMember("5", "42"), nil)
+	equals(tw.DeleteList("5") != nil, true)
+	equals(len(requests), 5)
+}
+
+func TestGetListTweets(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2/lists/5":
+			w.Write([]byte(`{"data": {"id": "5", "name": "gophers", "member_count": 2, "owner_id": "42"}}`))
+		case "/2/lists/5/tweets":
+			equals(r.URL.Query().Get("max_results"), "20")
+			w.Write([]byte(`{"data": [{"id": "1", "text": "go go go"}], "meta": {"next_token": "t2"}}`))
+		}
+	})
+
+	list, err := tw.GetList("5")
+	equals(err, nil)
+	equals(list.MemberCount, 2)
+	equals(list.OwnerID, "42")
+
+	page, err := tw.GetListTweets("5", TimelineOptions{MaxResults: 20})
+	equals(err, nil)
+	equals(page.Tweets[0].Text, "go go go")
+	equals(page.NextToken, "t2")
+}
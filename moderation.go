@@ -0,0 +1,90 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Block blocks the user for the authenticated user
+func (tw *Client) Block(userID string) error {
+	return tw.userAction(http.MethodPost, "blocking", "target_user_id", userID, "blocking", true)
+}
+
+// Unblock removes the block of the user
+func (tw *Client) Unblock(userID string) error {
+	return tw.userAction(http.MethodDelete, "blocking", "target_user_id", userID, "blocking", false)
+}
+
+// Mute mutes the user for the authenticated user
+func (tw *Client) Mute(userID string) error {
+	return tw.userAction(http.MethodPost, "muting", "target_user_id", userID, "muting", true)
+}
+
+// Unmute removes the mute of the user
+func (tw *Client) Unmute(userID string) error {
+	return tw.userAction(http.MethodDelete, "muting", "target_user_id", userID, "muting", false)
+}
+
+// GetBlockedUsers returns the users blocked by the authenticated user
+func (tw *Client) GetBlockedUsers(opts PageOptions) (UserPage, error) {
+	return tw.getOwnUserPage("blocking", opts)
+}
+
+// GetMutedUsers returns the users muted by the authenticated user
+func (tw *Client) GetMutedUsers(opts PageOptions) (UserPage, error) {
+	return tw.getOwnUserPage("muting", opts)
+}
+
+// getOwnUserPage requests a page of users from a collection of the authenticated user
+func (tw *Client) getOwnUserPage(collection string, opts PageOptions) (UserPage, error) {
+	userID, err := tw.authenticatedUserID()
+	if err != nil {
+		return UserPage{}, err
+	}
+	return tw.getUserPage(fmt.Sprintf("%s/users/%s/%s", apiRoot, userID, collection), opts)
+}
+
+// BulkReport lists the outcome of BulkApply for every user
+type BulkReport struct {
+	Succeeded []string
+	Failed    map[string]error
+}
+
+// BulkApply calls action, e.g. Client.Block or Client.Mute, for every user ID, waiting interval between calls.
+// When the rate limit is exhausted, it waits for the reset and retries the same user.
+// If ctx is done, the remaining users are reported as failed with the context error
+func BulkApply(ctx context.Context, userIDs []string, action func(userID string) error, interval time.Duration) BulkReport {
+	report := BulkReport{Failed: make(map[string]error)}
+
+	for i := 0; i < len(userIDs); i++ {
+		userID := userIDs[i]
+		if err := ctx.Err(); err != nil {
+			report.Failed[userID] = err
+			continue
+		}
+
+		err := action(userID)
+
+		var rateLimitErr *RateLimitError
+		if errors.As(err, &rateLimitErr) {
+			if waitErr := rateLimitErr.Wait(ctx); waitErr == nil {
+				i--
+				continue
+			}
+		}
+
+		if err != nil {
+			report.Failed[userID] = err
+		} else {
+			report.Succeeded = append(report.Succeeded, userID)
+		}
+
+		if i < len(userIDs)-1 {
+			sleep(ctx, interval)
+		}
+	}
+	return report
+}
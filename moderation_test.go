@@ -0,0 +1,107 @@
+package twitter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBulkApply(t *testing.T) {
+
+	var blocks []string
+	rateLimited := false
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/2/users/me" {
+			w.Write([]byte(`{"data": {"id": "42"}}`))
+			return
+		}
+		equals(r.URL.Path, "/2/users/42/blocking")
+
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		target := body["target_user_id"]
+
+		switch {
+		case target == "2" && !rateLimited:
+			rateLimited = true
+			w.Header().Set("x-rate-limit-reset", fmt.Sprint(time.Now().Unix()))
+			w.WriteHeader(http.StatusTooManyRequests)
+		case target == "3":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			blocks = append(blocks, target)
+			w.Write([]byte(`{"data": {"blocking": true}}`))
+		}
+	})
+
+	report := BulkApply(context.Background(), []string{"1", "2", "3", "4"}, tw.Block, time.Millisecond)
+	equals(fmt.Sprint(report.Succeeded), "[1 2 4]")
+	equals(fmt.Sprint(blocks), "[1 2 4]")
+	equals(len(report.Failed), 1)
+	equals(report.Failed["3"] != nil, true)
+}
+
+func TestBlockFromStream(t *testing.T) {
+
+	var mutex sync.Mutex
+	var blocked []string
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2/tweets/search/stream":
+			writeStreamedTweets(w, "r1", "1", "2", "3")
+		case "/2/users/me":
+			w.Write([]byte(`{"data": {"id": "42"}}`))
+		case "/2/users/42/blocking":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			mutex.Lock()
+			blocked = append(blocked, body["target_user_id"])
+			mutex.Unlock()
+			w.Write([]byte(`{"data": {"blocking": true}}`))
+		}
+	})
+
+	sub := tw.SubscribeStream(StreamRule{ID: "r1"})
+	tw.StartStream()
+
+	// the stream keeps forwarding tweets while the subscriber blocks the previous author
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 3; i++ {
+			tweet := <-sub.Tweets
+			equals(tw.Block(tweet.Author.ID), nil)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Block did not return while the stream was forwarding tweets")
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	equals(strings.Join(blocked, ","), "a1,a2,a3")
+}
+
+func TestGetMutedUsers(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2/users/me":
+			w.Write([]byte(`{"data": {"id": "42"}}`))
+		case "/2/users/42/muting":
+			w.Write([]byte(`{"data": [{"id": "7", "username": "loud"}], "meta": {"next_token": "n"}}`))
+		}
+	})
+
+	page, err := tw.GetMutedUsers(PageOptions{})
+	equals(err, nil)
+	equals(page.Users[0].Handle, "loud")
+	equals(page.NextToken, "n")
+}
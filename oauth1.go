@@ -0,0 +1,133 @@
+package twitter
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OAuth1 signs requests on behalf of a user using OAuth 1.0a with HMAC-SHA1
+type OAuth1 struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	AccessToken    string
+	AccessSecret   string
+
+	// used in tests to create predictable signatures
+	nonce func() string
+	now   func() time.Time
+}
+
+// Authenticate signs the request and sets the Authorization header.
+// Query parameters and form encoded bodies are part of the signature, json bodies are not
+func (o *OAuth1) Authenticate(req *http.Request) error {
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     o.ConsumerKey,
+		"oauth_nonce":            o.makeNonce(),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(o.makeTime().Unix(), 10),
+		"oauth_token":            o.AccessToken,
+		"oauth_version":          "1.0",
+	}
+
+	params := url.Values{}
+	for key, value := range req.URL.Query() {
+		params[key] = value
+	}
+	formParams, err := formBody(req)
+	if err != nil {
+		return err
+	}
+	for key, value := range formParams {
+		params[key] = append(params[key], value...)
+	}
+	for key, value := range oauthParams {
+		params.Set(key, value)
+	}
+
+	oauthParams["oauth_signature"] = o.signature(req.Method, req.URL, params)
+
+	keys := make([]string, 0, len(oauthParams))
+	for key := range oauthParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	header := make([]string, len(keys))
+	for i, key := range keys {
+		header[i] = percentEncode(key) + `="` + percentEncode(oauthParams[key]) + `"`
+	}
+	req.Header.Set("Authorization", "OAuth "+strings.Join(header, ", "))
+	return nil
+}
+
+// signature computes the HMAC-SHA1 signature of the request
+func (o *OAuth1) signature(method string, requestURL *url.URL, params url.Values) string {
+	var pairs []string
+	for key, values := range params {
+		for _, value := range values {
+			pairs = append(pairs, percentEncode(key)+"="+percentEncode(value))
+		}
+	}
+	sort.Strings(pairs)
+
+	baseURL := *requestURL
+	baseURL.RawQuery = ""
+	baseURL.Fragment = ""
+	baseURL.Scheme = strings.ToLower(baseURL.Scheme)
+	baseURL.Host = strings.ToLower(baseURL.Host)
+
+	base := strings.ToUpper(method) + "&" + percentEncode(baseURL.String()) + "&" + percentEncode(strings.Join(pairs, "&"))
+	key := percentEncode(o.ConsumerSecret) + "&" + percentEncode(o.AccessSecret)
+
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(base))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (o *OAuth1) makeNonce() string {
+	if o.nonce != nil {
+		return o.nonce()
+	}
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func (o *OAuth1) makeTime() time.Time {
+	if o.now != nil {
+		return o.now()
+	}
+	return time.Now()
+}
+
+// formBody returns the parameters of a form encoded request body and restores the body for sending
+func formBody(req *http.Request) (url.Values, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType != "application/x-www-form-urlencoded" {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	return url.ParseQuery(string(data))
+}
@@ -0,0 +1,40 @@
+package twitter
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestOAuth1Signature uses the example from https://developer.twitter.com/en/docs/authentication/oauth-1-0a/creating-a-signature
+func TestOAuth1Signature(t *testing.T) {
+
+	auth := &OAuth1{
+		ConsumerKey:    "xvz1evFS4wEEPTGEFPHBog",
+		ConsumerSecret: "kAcSOqF21Fu85e7zjz7ZN2U4ZRhfV3WpwPAoE3Z7kBw",
+		AccessToken:    "370773112-GmHxMAgYyLbNEtIKZeRNFsMKPR9EyMZeS9weJAEb",
+		AccessSecret:   "LswwdoUaIvS8ltyTt5jkRh4J50vUPVVHtR2YPi5kE",
+		nonce:          func() string { return "kYjzVBB8Y0ZFabxSWbWovY3uYSQ2pTgmZeNu2VS4cg" },
+		now:            func() time.Time { return time.Unix(1318622958, 0) },
+	}
+
+	body := "status=Hello%20Ladies%20%2b%20Gentlemen%2c%20a%20signed%20OAuth%20request%21"
+	req, err := http.NewRequest(http.MethodPost, "https://api.twitter.com/1.1/statuses/update.json?include_entities=true",
+		strings.NewReader(body))
+	equals(err, nil)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	equals(auth.Authenticate(req), nil)
+
+	header := req.Header.Get("Authorization")
+	equals(strings.HasPrefix(header, "OAuth "), true)
+	equals(strings.Contains(header, `oauth_signature="hCtSmYh%2BiHYCEqBWrE7C7hYmtUk%3D"`), true)
+	equals(strings.Contains(header, `oauth_token="370773112-GmHxMAgYyLbNEtIKZeRNFsMKPR9EyMZeS9weJAEb"`), true)
+
+	// the body can still be sent
+	sent, err := ioutil.ReadAll(req.Body)
+	equals(err, nil)
+	equals(string(sent), body)
+}
@@ -0,0 +1,198 @@
+package twitter
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	oauth2AuthorizeURL = "https://twitter.com/i/oauth2/authorize"
+	oauth2TokenURL     = "https://api.twitter.com/2/oauth2/token"
+)
+
+// refreshMargin is how long before its expiry an access token is refreshed
+const refreshMargin = time.Minute
+
+// OAuth2Config describes an app using the OAuth 2.0 Authorization Code Flow with PKCE
+type OAuth2Config struct {
+	ClientID     string
+	ClientSecret string // only set for confidential clients
+	RedirectURL  string
+	Scopes       []string     // e.g. "tweet.read", "users.read" and "offline.access" to receive a refresh token
+	HTTPClient   *http.Client // used for token requests if set
+}
+
+// OAuth2Token is the token of a user authorized by an OAuth2Config
+type OAuth2Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// TokenStore persists the OAuth2Token of a user. Refreshing a token invalidates the old refresh token,
+// so the rotated token needs to be saved before it is used
+type TokenStore interface {
+	Load() (OAuth2Token, error)
+	Save(token OAuth2Token) error
+}
+
+// MemoryTokenStore keeps the token in memory
+type MemoryTokenStore struct {
+	token OAuth2Token
+	sync.Mutex
+}
+
+// Load returns the stored token
+func (s *MemoryTokenStore) Load() (OAuth2Token, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.token, nil
+}
+
+// Save replaces the stored token
+func (s *MemoryTokenStore) Save(token OAuth2Token) error {
+	s.Lock()
+	defer s.Unlock()
+	s.token = token
+	return nil
+}
+
+// NewPKCE creates a random code verifier and the matching S256 code challenge.
+// The challenge is passed to AuthorizeURL and the verifier to Exchange
+func NewPKCE() (verifier, challenge string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return
+	}
+	verifier = base64.RawURLEncoding.EncodeToString(buf)
+	sum := sha256.Sum256([]byte(verifier))
+	challenge = base64.RawURLEncoding.EncodeToString(sum[:])
+	return verifier, challenge, nil
+}
+
+// AuthorizeURL returns the url the user has to visit to authorize the app.
+// Afterwards, twitter redirects to RedirectURL with the state and a code for Exchange
+func (c OAuth2Config) AuthorizeURL(state, codeChallenge string) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", c.ClientID)
+	params.Set("redirect_uri", c.RedirectURL)
+	params.Set("scope", strings.Join(c.Scopes, " "))
+	params.Set("state", state)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+	return oauth2AuthorizeURL + "?" + params.Encode()
+}
+
+// Exchange trades the code received at RedirectURL for a token
+func (c OAuth2Config) Exchange(code, codeVerifier string) (OAuth2Token, error) {
+	params := url.Values{}
+	params.Set("grant_type", "authorization_code")
+	params.Set("code", code)
+	params.Set("redirect_uri", c.RedirectURL)
+	params.Set("code_verifier", codeVerifier)
+	return c.requestToken(params)
+}
+
+// Refresh uses the refresh token to get a new token, which contains a new refresh token
+func (c OAuth2Config) Refresh(refreshToken string) (OAuth2Token, error) {
+	params := url.Values{}
+	params.Set("grant_type", "refresh_token")
+	params.Set("refresh_token", refreshToken)
+	return c.requestToken(params)
+}
+
+// requestToken posts params to the token endpoint
+func (c OAuth2Config) requestToken(params url.Values) (token OAuth2Token, err error) {
+	params.Set("client_id", c.ClientID)
+
+	req, err := http.NewRequest(http.MethodPost, oauth2TokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if c.ClientSecret != "" {
+		req.SetBasicAuth(c.ClientID, c.ClientSecret)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	result, err := client.Do(req)
+	if err != nil {
+		return
+	}
+	defer result.Body.Close()
+
+	if result.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(result.Body)
+		return token, fmt.Errorf("failed to get token. Response: %s", string(data))
+	}
+
+	var response struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		Scope        string `json:"scope"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	err = json.NewDecoder(result.Body).Decode(&response)
+	if err != nil {
+		return
+	}
+
+	return OAuth2Token{
+		AccessToken:  response.AccessToken,
+		RefreshToken: response.RefreshToken,
+		Scope:        response.Scope,
+		Expiry:       time.Now().Add(time.Duration(response.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// OAuth2 authenticates requests with the users token from Store. Expired tokens are refreshed
+// and the rotated token is saved to Store
+type OAuth2 struct {
+	Config OAuth2Config
+	Store  TokenStore
+	sync.Mutex
+}
+
+// Authenticate sets the Authorization header, refreshing the token first if needed
+func (o *OAuth2) Authenticate(req *http.Request) error {
+	token, err := o.Token()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return nil
+}
+
+// Token returns a valid token, refreshing it if it is about to expire
+func (o *OAuth2) Token() (OAuth2Token, error) {
+	o.Lock()
+	defer o.Unlock()
+
+	token, err := o.Store.Load()
+	if err != nil {
+		return token, err
+	}
+	if token.RefreshToken == "" || token.Expiry.IsZero() || time.Until(token.Expiry) > refreshMargin {
+		return token, nil
+	}
+
+	token, err = o.Config.Refresh(token.RefreshToken)
+	if err != nil {
+		return token, err
+	}
+	return token, o.Store.Save(token)
+}
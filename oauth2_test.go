@@ -0,0 +1,67 @@
+package twitter
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestNewPKCE(t *testing.T) {
+	verifier, challenge, err := NewPKCE()
+	equals(err, nil)
+
+	sum := sha256.Sum256([]byte(verifier))
+	equals(challenge, base64.RawURLEncoding.EncodeToString(sum[:]))
+
+	config := OAuth2Config{ClientID: "client", RedirectURL: "https://example.org/callback", Scopes: []string{"tweet.read", "users.read"}}
+	authorizeURL, err := url.Parse(config.AuthorizeURL("state", challenge))
+	equals(err, nil)
+	equals(authorizeURL.Host, "twitter.com")
+	equals(authorizeURL.Query().Get("scope"), "tweet.read users.read")
+	equals(authorizeURL.Query().Get("code_challenge"), challenge)
+	equals(authorizeURL.Query().Get("code_challenge_method"), "S256")
+}
+
+func TestOAuth2Refresh(t *testing.T) {
+
+	var grants []string
+	httpClient := newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.URL.Path, "/2/oauth2/token")
+		equals(r.FormValue("client_id"), "client")
+		grants = append(grants, r.FormValue("grant_type"))
+
+		switch r.FormValue("grant_type") {
+		case "authorization_code":
+			equals(r.FormValue("code_verifier"), "verifier")
+			w.Write([]byte(`{"access_token": "access1", "refresh_token": "refresh1", "expires_in": 30}`))
+		case "refresh_token":
+			equals(r.FormValue("refresh_token"), "refresh1")
+			w.Write([]byte(`{"access_token": "access2", "refresh_token": "refresh2", "expires_in": 7200}`))
+		}
+	})
+
+	config := OAuth2Config{ClientID: "client", HTTPClient: httpClient}
+	token, err := config.Exchange("code", "verifier")
+	equals(err, nil)
+	equals(token.AccessToken, "access1")
+
+	store := &MemoryTokenStore{}
+	equals(store.Save(token), nil)
+
+	// the token expires within the refresh margin, so it is rotated before use
+	auth := &OAuth2{Config: config, Store: store}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.twitter.com/2/users/me", nil)
+	equals(auth.Authenticate(req), nil)
+	equals(req.Header.Get("Authorization"), "Bearer access2")
+
+	saved, _ := store.Load()
+	equals(saved.RefreshToken, "refresh2")
+	equals(saved.Expiry.After(time.Now().Add(time.Hour)), true)
+
+	// the rotated token is still valid
+	equals(auth.Authenticate(req), nil)
+	equals(len(grants), 2)
+}
@@ -0,0 +1,103 @@
+package twitter
+
+import "fmt"
+
+const pollFields = "poll.fields=duration_minutes,end_datetime,voting_status,options"
+
+// Poll voting states used by Poll.VotingStatus
+const (
+	PollOpen   = "open"
+	PollClosed = "closed"
+)
+
+// ErrNoPoll is returned by GetPoll when the tweet has no poll attached
+var ErrNoPoll = fmt.Errorf("tweet has no poll")
+
+// poll is a poll as given by the api
+type poll struct {
+	ID              string       `json:"id"`
+	Options         []PollOption `json:"options"`
+	VotingStatus    string       `json:"voting_status"`
+	EndTime         string       `json:"end_datetime"`
+	DurationMinutes int          `json:"duration_minutes"`
+}
+
+// Poll represents a poll attached to a tweet
+type Poll struct {
+	ID              string       `json:"id"`
+	Options         []PollOption `json:"options"`
+	VotingStatus    string       `json:"votingStatus"`
+	EndTime         string       `json:"endTime"`
+	DurationMinutes int          `json:"durationMinutes"`
+}
+
+// convertToPoll converts twitters poll object into a Poll
+func convertToPoll(p poll) Poll {
+	return Poll{
+		ID:              p.ID,
+		Options:         p.Options,
+		VotingStatus:    p.VotingStatus,
+		EndTime:         p.EndTime,
+		DurationMinutes: p.DurationMinutes,
+	}
+}
+
+// IsOpen reports whether the poll still accepts votes
+func (p Poll) IsOpen() bool {
+	return p.VotingStatus == PollOpen
+}
+
+// TotalVotes returns the sum of votes over all options
+func (p Poll) TotalVotes() int {
+	total := 0
+	for _, option := range p.Options {
+		total += option.Votes
+	}
+	return total
+}
+
+// Percentage returns the share of votes for the option at position, ranging from 0 to 100.
+// It returns 0 if nobody voted or there is no option at that position
+func (p Poll) Percentage(position int) float64 {
+	total := p.TotalVotes()
+	if total == 0 {
+		return 0
+	}
+	for _, option := range p.Options {
+		if option.Position == position {
+			return float64(option.Votes) / float64(total) * 100
+		}
+	}
+	return 0
+}
+
+// Percentages returns the share of votes of every option in the order of Options
+func (p Poll) Percentages() []float64 {
+	percentages := make([]float64, len(p.Options))
+	for i, option := range p.Options {
+		percentages[i] = p.Percentage(option.Position)
+	}
+	return percentages
+}
+
+// GetPoll looks up the tweet and returns the current state of its poll.
+// Use it to refresh the results of open polls.
+// Deleted tweets and tweets of protected users are reported as an *APIError
+func (tw *Client) GetPoll(tweetID string) (poll Poll, err error) {
+	uri := fmt.Sprintf("%s/tweets/%s?expansions=attachments.poll_ids&%s", apiRoot, tweetID, pollFields)
+
+	var response tweetResponse
+	err = tw.getJSON(uri, &response)
+	if err != nil {
+		return
+	}
+	if response.Tweet.ID == "" && len(response.Errors) > 0 {
+		return poll, &response.Errors[0]
+	}
+
+	tweet := convertToTweet(response.Tweet, response.Includes, nil)
+	if tweet.PollDetails == nil {
+		return poll, ErrNoPoll
+	}
+	return *tweet.PollDetails, nil
+}
@@ -0,0 +1,62 @@
+package twitter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetPoll(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.URL.Path, "/2/tweets/123")
+		equals(r.Header.Get("Authorization"), "Bearer token")
+		w.Write([]byte(`{
+			"data": {"id": "123", "text": "vote!", "attachments": {"poll_ids": ["p1"]}},
+			"includes": {"polls": [{
+				"id": "p1",
+				"voting_status": "open",
+				"end_datetime": "2022-01-01T12:00:00.000Z",
+				"duration_minutes": 60,
+				"options": [
+					{"position": 1, "label": "yes", "votes": 3},
+					{"position": 2, "label": "no", "votes": 1}
+				]
+			}]}
+		}`))
+	})
+
+	poll, err := tw.GetPoll("123")
+	equals(err, nil)
+	equals(poll.ID, "p1")
+	equals(poll.IsOpen(), true)
+	equals(poll.EndTime, "2022-01-01T12:00:00.000Z")
+	equals(poll.DurationMinutes, 60)
+	equals(poll.TotalVotes(), 4)
+	equals(poll.Percentage(1), 75.0)
+	equals(poll.Percentages()[1], 25.0)
+	equals(poll.Percentage(3), 0.0)
+
+	tw = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"id": "123", "text": "no poll"}}`))
+	})
+	_, err = tw.GetPoll("123")
+	equals(err, ErrNoPoll)
+
+	tw = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors": [{"resource_id": "123", "title": "Not Found Error",
+			"type": "https://api.twitter.com/2/problems/resource-not-found"}]}`))
+	})
+	_, err = tw.GetPoll("123")
+	equals(errors.Is(err, ErrNotFound), true)
+
+	tw = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-rate-limit-reset", fmt.Sprint(time.Now().Unix()))
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	_, err = tw.GetPoll("123")
+	var rateLimitErr *RateLimitError
+	equals(errors.As(err, &rateLimitErr), true)
+}
@@ -0,0 +1,88 @@
+package twitter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SearchRecentPage calls the /2/tweets/search/recent endpoint with the query and returns one page of results,
+// newest first. Unlike SearchRecent, it supports pagination and time or ID bounds.
+// ExcludeRetweets and ExcludeReplies are not supported, use ExcludeRetweetsFilter in the query instead
+func (tw *Client) SearchRecentPage(query string, opts TimelineOptions) (TimelinePage, error) {
+	params := opts.values()
+	params.Set("query", strings.TrimSpace(query))
+	params.Del("exclude")
+	// the search endpoint calls the pagination token next_token
+	if token := params.Get("pagination_token"); token != "" {
+		params.Del("pagination_token")
+		params.Set("next_token", token)
+	}
+
+	uri := withQuery(fmt.Sprintf("%s/tweets/search/recent", apiRoot), params.Encode(), tw.fields(opts.Fields).Query())
+
+	var response searchResponse
+	err := tw.getJSON(uri, &response)
+	if err != nil {
+		return TimelinePage{}, err
+	}
+
+	return TimelinePage{
+		Tweets:    tweetsFromSearchResult(response),
+		NewestID:  response.Meta.NewestID,
+		OldestID:  response.Meta.OldestID,
+		NextToken: response.Meta.NextToken,
+	}, nil
+}
+
+// SubscribePolling is an alternative to SubscribeStream for tokens without access to the filtered stream.
+// It runs the rule as a query against SearchRecentPage every interval and sends new matching tweets to the
+// Tweets channel of the returned StreamSubscription, oldest first and without duplicates. The tweets have
+// the ID of the rule in RuleIDs, like streamed tweets. Since the recent search endpoint allows 450 requests per
+// 15 minutes for app-only authentication, interval should not be shorter than 2 seconds. When the rate limit is
+// exhausted, polling pauses until it resets.
+// Polling stops when ctx is done or the subscription is passed to UnsubscribeStream, after which Tweets is closed
+func (tw *Client) SubscribePolling(ctx context.Context, rule StreamRule, interval time.Duration) StreamSubscription {
+	ctx, cancel := context.WithCancel(ctx)
+	sub := StreamSubscription{Tweets: make(chan Tweet), Rule: rule}
+
+	tw.Lock()
+	if tw.pollers == nil {
+		tw.pollers = make(map[chan Tweet]context.CancelFunc)
+	}
+	tw.pollers[sub.Tweets] = cancel
+	tw.Unlock()
+
+	fetch := func(opts TimelineOptions) (TimelinePage, error) {
+		page, err := tw.SearchRecentPage(rule.Rule, opts)
+		for i := range page.Tweets {
+			if rule.ID != "" {
+				page.Tweets[i].RuleIDs = []string{rule.ID}
+			}
+		}
+		return page, err
+	}
+
+	go func() {
+		tw.watchTimeline(ctx, fetch, TimelineOptions{MaxResults: 100}, interval, sub.Tweets)
+
+		tw.Lock()
+		delete(tw.pollers, sub.Tweets)
+		tw.Unlock()
+	}()
+
+	return sub
+}
+
+// unsubscribePolling stops the poller of the subscription and reports whether it was a polling subscription
+func (tw *Client) unsubscribePolling(sub StreamSubscription) bool {
+	tw.Lock()
+	cancel, ok := tw.pollers[sub.Tweets]
+	tw.Unlock()
+
+	if ok {
+		cancel()
+	}
+	return ok
+}
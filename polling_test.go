@@ -0,0 +1,51 @@
+package twitter
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSubscribePolling(t *testing.T) {
+
+	var mutex sync.Mutex
+	polls := 0
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		polls++
+
+		equals(r.URL.Path, "/2/tweets/search/recent")
+		query := r.URL.Query()
+		equals(query.Get("query"), "golang has:images")
+		equals(query.Get("pagination_token"), "")
+
+		switch {
+		case polls == 1:
+			w.Write([]byte(`{"data": [{"id": "10"}], "meta": {"newest_id": "10"}}`))
+		case polls == 2:
+			equals(query.Get("since_id"), "10")
+			w.Write([]byte(`{"data": [{"id": "12", "text": "b"}], "meta": {"newest_id": "12", "next_token": "more"}}`))
+		case polls == 3:
+			equals(query.Get("next_token"), "more")
+			w.Write([]byte(`{"data": [{"id": "11", "text": "a"}], "meta": {"newest_id": "11"}}`))
+		default:
+			// a tweet that was already delivered is not sent again
+			w.Write([]byte(`{"data": [{"id": "12", "text": "b"}], "meta": {"newest_id": "12"}}`))
+		}
+	})
+
+	rule := StreamRule{ID: "r1", Rule: " golang " + ImageFilter}
+	sub := tw.SubscribePolling(context.Background(), rule, time.Millisecond)
+
+	tweet := <-sub.Tweets
+	equals(tweet.Text, "a")
+	equals(tweet.RuleIDs[0], "r1")
+	equals((<-sub.Tweets).Text, "b")
+
+	tw.UnsubscribeStream(sub)
+	_, open := <-sub.Tweets
+	equals(open, false)
+}
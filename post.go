@@ -0,0 +1,122 @@
+package twitter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Values for NewTweet.ReplySettings that limit who can reply. By default everyone can reply
+const (
+	ReplySettingsMentionedUsers = "mentionedUsers"
+	ReplySettingsFollowing      = "following"
+)
+
+// ErrNoPollDuration is returned by CreateTweet when a poll is attached without a duration
+var ErrNoPollDuration = errors.New("poll duration is not set")
+
+// NewTweet describes a tweet to be created using CreateTweet
+type NewTweet struct {
+	Text string
+	// ReplyTo is the ID of the tweet to reply to. Twitter mentions the users of the conversation automatically,
+	// unless they are listed in ExcludeReplyUserIDs
+	ReplyTo             string
+	ExcludeReplyUserIDs []string
+	QuoteTweetID        string
+	// PollOptions attaches a poll with 2 to 4 options, which runs for PollDurationMinutes.
+	// There is no default duration, CreateTweet returns ErrNoPollDuration if it is not set
+	PollOptions           []string
+	PollDurationMinutes   int
+	MediaIDs              []string // IDs of uploaded media, see UploadMedia
+	TaggedUserIDs         []string // users tagged in the attached media
+	ReplySettings         string
+	ForSuperFollowersOnly bool
+}
+
+// createTweetRequest is the body of a POST /2/tweets request
+type createTweetRequest struct {
+	Text                  string        `json:"text,omitempty"`
+	Reply                 *replyRequest `json:"reply,omitempty"`
+	QuoteTweetID          string        `json:"quote_tweet_id,omitempty"`
+	Poll                  *pollRequest  `json:"poll,omitempty"`
+	Media                 *mediaRequest `json:"media,omitempty"`
+	ReplySettings         string        `json:"reply_settings,omitempty"`
+	ForSuperFollowersOnly bool          `json:"for_super_followers_only,omitempty"`
+}
+
+type replyRequest struct {
+	InReplyToTweetID    string   `json:"in_reply_to_tweet_id"`
+	ExcludeReplyUserIDs []string `json:"exclude_reply_user_ids,omitempty"`
+}
+
+type pollRequest struct {
+	Options         []string `json:"options"`
+	DurationMinutes int      `json:"duration_minutes"`
+}
+
+type mediaRequest struct {
+	MediaIDs      []string `json:"media_ids"`
+	TaggedUserIDs []string `json:"tagged_user_ids,omitempty"`
+}
+
+// CreateTweet posts a new tweet for the authenticated user.
+// Only ID, Text and EditHistoryTweetIDs of the returned Tweet are set
+func (tw *Client) CreateTweet(newTweet NewTweet) (created Tweet, err error) {
+	reqBody := createTweetRequest{
+		Text:                  newTweet.Text,
+		QuoteTweetID:          newTweet.QuoteTweetID,
+		ReplySettings:         newTweet.ReplySettings,
+		ForSuperFollowersOnly: newTweet.ForSuperFollowersOnly,
+	}
+	if newTweet.ReplyTo != "" {
+		reqBody.Reply = &replyRequest{newTweet.ReplyTo, newTweet.ExcludeReplyUserIDs}
+	}
+	if len(newTweet.PollOptions) > 0 {
+		if newTweet.PollDurationMinutes <= 0 {
+			return created, ErrNoPollDuration
+		}
+		reqBody.Poll = &pollRequest{newTweet.PollOptions, newTweet.PollDurationMinutes}
+	}
+	if len(newTweet.MediaIDs) > 0 {
+		reqBody.Media = &mediaRequest{newTweet.MediaIDs, newTweet.TaggedUserIDs}
+	}
+
+	var response tweetResponse
+	err = tw.sendJSON(http.MethodPost, fmt.Sprintf("%s/tweets", apiRoot), &reqBody, &response)
+	if err != nil {
+		return
+	}
+
+	return Tweet{
+		ID:                  response.Tweet.ID,
+		Text:                response.Tweet.Text,
+		EditHistoryTweetIDs: response.Tweet.EditHistoryTweetIDs,
+	}, nil
+}
+
+// Reply is a shorthand for CreateTweet replying to the tweet with the given ID
+func (tw *Client) Reply(tweetID, text string) (Tweet, error) {
+	return tw.CreateTweet(NewTweet{Text: text, ReplyTo: tweetID})
+}
+
+// Quote is a shorthand for CreateTweet quoting the tweet with the given ID
+func (tw *Client) Quote(tweetID, text string) (Tweet, error) {
+	return tw.CreateTweet(NewTweet{Text: text, QuoteTweetID: tweetID})
+}
+
+// DeleteTweet deletes a tweet of the authenticated user
+func (tw *Client) DeleteTweet(tweetID string) error {
+	var response struct {
+		Data struct {
+			Deleted bool `json:"deleted"`
+		} `json:"data"`
+	}
+	err := tw.sendJSON(http.MethodDelete, fmt.Sprintf("%s/tweets/%s", apiRoot, tweetID), nil, &response)
+	if err != nil {
+		return err
+	}
+	if !response.Data.Deleted {
+		return errors.New("failed to delete tweet")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package twitter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestCreateTweet(t *testing.T) {
+
+	var body string
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.Method, http.MethodPost)
+		equals(r.URL.Path, "/2/tweets")
+		equals(r.Header.Get("Content-Type"), "application/json")
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "99", "text": "which one?", "edit_history_tweet_ids": ["99"]}}`))
+	})
+
+	created, err := tw.CreateTweet(NewTweet{
+		Text:                "which one?",
+		ReplyTo:             "1",
+		ExcludeReplyUserIDs: []string{"7"},
+		PollOptions:         []string{"a", "b"},
+		PollDurationMinutes: 60,
+		ReplySettings:       ReplySettingsFollowing,
+	})
+	equals(err, nil)
+	equals(created.ID, "99")
+	equals(created.Text, "which one?")
+
+	var sent map[string]interface{}
+	equals(json.Unmarshal([]byte(body), &sent), nil)
+	equals(sent["reply"].(map[string]interface{})["in_reply_to_tweet_id"], "1")
+	equals(sent["poll"].(map[string]interface{})["duration_minutes"], 60.0)
+	equals(sent["reply_settings"], "following")
+	equals(sent["quote_tweet_id"], nil)
+	equals(sent["media"], nil)
+
+	// the request is not sent without a poll duration
+	body = ""
+	_, err = tw.CreateTweet(NewTweet{Text: "which one?", PollOptions: []string{"a", "b"}})
+	equals(err, ErrNoPollDuration)
+	equals(body, "")
+}
+
+func TestDeleteTweet(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.Method, http.MethodDelete)
+		equals(r.URL.Path, "/2/tweets/99")
+		w.Write([]byte(`{"data": {"deleted": true}}`))
+	})
+
+	equals(tw.DeleteTweet("99"), nil)
+}
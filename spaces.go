@@ -0,0 +1,235 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const spaceFieldsAndExpansions = "space.fields=id,state,title,created_at,started_at,ended_at,scheduled_start,participant_count," +
+	"creator_id,host_ids,speaker_ids,lang,is_ticketed" +
+	"&expansions=creator_id,host_ids,speaker_ids" +
+	"&user.fields=profile_image_url,verified"
+
+// Space states used by Space.State and SearchSpaces
+const (
+	SpaceLive      = "live"
+	SpaceScheduled = "scheduled"
+	SpaceEnded     = "ended"
+	SpaceAll       = "all" // only valid for SearchSpaces
+)
+
+// Space represents an audio Space
+type Space struct {
+	ID               string `json:"id"`
+	Title            string `json:"title"`
+	State            string `json:"state"`
+	CreatedAt        string `json:"createdAt,omitempty"`
+	ScheduledStart   string `json:"scheduledStart,omitempty"`
+	StartedAt        string `json:"startedAt,omitempty"`
+	EndedAt          string `json:"endedAt,omitempty"`
+	ParticipantCount int    `json:"participantCount"`
+	Lang             string `json:"lang,omitempty"`
+	IsTicketed       bool   `json:"isTicketed"`
+	CreatorID        string `json:"creatorID"`
+	Creator          *User  `json:"creator,omitempty"`
+	Hosts            []User `json:"hosts,omitempty"`
+	Speakers         []User `json:"speakers,omitempty"`
+}
+
+// space is a space as given by the api
+type space struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	State            string   `json:"state"`
+	CreatedAt        string   `json:"created_at"`
+	ScheduledStart   string   `json:"scheduled_start"`
+	StartedAt        string   `json:"started_at"`
+	EndedAt          string   `json:"ended_at"`
+	ParticipantCount int      `json:"participant_count"`
+	Lang             string   `json:"lang"`
+	IsTicketed       bool     `json:"is_ticketed"`
+	CreatorID        string   `json:"creator_id"`
+	HostIDs          []string `json:"host_ids"`
+	SpeakerIDs       []string `json:"speaker_ids"`
+}
+
+// spacesResponse represents the data returned by the spaces endpoints
+type spacesResponse struct {
+	Spaces   []space    `json:"data"`
+	Includes includes   `json:"includes"`
+	Errors   []APIError `json:"errors"`
+}
+
+// convertToSpace resolves the users of a space from the includes
+func convertToSpace(s space, incl includes) Space {
+	users := make(map[string]User, len(incl.Users))
+	for _, u := range incl.Users {
+		users[u.ID] = convertToUser(u)
+	}
+	resolve := func(ids []string) []User {
+		var resolved []User
+		for _, id := range ids {
+			if u, ok := users[id]; ok {
+				resolved = append(resolved, u)
+			}
+		}
+		return resolved
+	}
+
+	converted := Space{
+		ID:               s.ID,
+		Title:            s.Title,
+		State:            s.State,
+		CreatedAt:        s.CreatedAt,
+		ScheduledStart:   s.ScheduledStart,
+		StartedAt:        s.StartedAt,
+		EndedAt:          s.EndedAt,
+		ParticipantCount: s.ParticipantCount,
+		Lang:             s.Lang,
+		IsTicketed:       s.IsTicketed,
+		CreatorID:        s.CreatorID,
+		Hosts:            resolve(s.HostIDs),
+		Speakers:         resolve(s.SpeakerIDs),
+	}
+	if creator, ok := users[s.CreatorID]; ok {
+		converted.Creator = &creator
+	}
+	return converted
+}
+
+// GetSpaces returns the Spaces with the given IDs, split into requests of 100 IDs.
+// Spaces that could not be found are left out and reported in errs by their ID.
+// err is only set if a request failed as a whole
+func (tw *Client) GetSpaces(spaceIDs []string) (spaces []Space, errs map[string]error, err error) {
+	return tw.getSpaces(spaceIDs, func(chunk []string) string {
+		return fmt.Sprintf("%s/spaces?ids=%s&%s", apiRoot, strings.Join(chunk, ","), spaceFieldsAndExpansions)
+	})
+}
+
+// GetSpacesByCreators returns the live and scheduled Spaces created by the users, split into requests of 100 IDs
+func (tw *Client) GetSpacesByCreators(userIDs []string) (spaces []Space, errs map[string]error, err error) {
+	return tw.getSpaces(userIDs, func(chunk []string) string {
+		return fmt.Sprintf("%s/spaces/by/creator_ids?user_ids=%s&%s", apiRoot, strings.Join(chunk, ","), spaceFieldsAndExpansions)
+	})
+}
+
+// SearchSpaces returns the Spaces whose title matches the query. state is one of SpaceLive,
+// SpaceScheduled or SpaceAll
+func (tw *Client) SearchSpaces(query, state string) (spaces []Space, err error) {
+	params := url.Values{}
+	params.Set("query", query)
+	if state != "" {
+		params.Set("state", state)
+	}
+
+	var response spacesResponse
+	err = tw.getJSON(fmt.Sprintf("%s/spaces/search?%s&%s", apiRoot, params.Encode(), spaceFieldsAndExpansions), &response)
+	if err != nil {
+		return
+	}
+	for _, s := range response.Spaces {
+		spaces = append(spaces, convertToSpace(s, response.Includes))
+	}
+	return spaces, nil
+}
+
+// getSpaces requests the spaces in chunks, using uri to build the request url for each chunk
+func (tw *Client) getSpaces(ids []string, uri func(chunk []string) string) (spaces []Space, errs map[string]error, err error) {
+	errs = make(map[string]error)
+
+	for _, chunk := range chunkIDs(ids, maxLookupIDs) {
+		var response spacesResponse
+		err = tw.getJSON(uri(chunk), &response)
+		if err != nil {
+			return
+		}
+
+		for _, s := range response.Spaces {
+			spaces = append(spaces, convertToSpace(s, response.Includes))
+		}
+		for i := range response.Errors {
+			errs[response.Errors[i].ResourceID] = &response.Errors[i]
+		}
+	}
+	return spaces, errs, nil
+}
+
+// SpaceWatcher contains a channel Live which receives watched Spaces when they go live
+type SpaceWatcher struct {
+	Live   chan Space
+	cancel context.CancelFunc
+}
+
+// WatchSpaces polls the Spaces with the given IDs every interval and sends each Space to
+// SpaceWatcher.Live once it is live, including Spaces that are already live when the watcher starts.
+// Polling stops when ctx is done, Stop is called or every watched Space ended or could not be found,
+// after which Live is closed
+func (tw *Client) WatchSpaces(ctx context.Context, spaceIDs []string, interval time.Duration) *SpaceWatcher {
+	ctx, cancel := context.WithCancel(ctx)
+	watcher := &SpaceWatcher{Live: make(chan Space), cancel: cancel}
+	go tw.watchSpaces(ctx, spaceIDs, interval, watcher.Live)
+	return watcher
+}
+
+// Stop ends polling and closes Live
+func (w *SpaceWatcher) Stop() {
+	w.cancel()
+}
+
+func (tw *Client) watchSpaces(ctx context.Context, spaceIDs []string, interval time.Duration, live chan<- Space) {
+	defer close(live)
+
+	pending := make(map[string]bool, len(spaceIDs))
+	for _, id := range spaceIDs {
+		pending[id] = true
+	}
+
+	for len(pending) > 0 {
+		ids := make([]string, 0, len(pending))
+		for id := range pending {
+			ids = append(ids, id)
+		}
+
+		spaces, errs, err := tw.GetSpaces(ids)
+
+		var rateLimitErr *RateLimitError
+		switch {
+		case errors.As(err, &rateLimitErr):
+			if rateLimitErr.Wait(ctx) != nil {
+				return
+			}
+			continue
+		case err != nil:
+			tw.logger.Printf("failed to poll spaces: %s", err)
+		}
+
+		// deleted or unknown Spaces will never go live
+		for id, spaceErr := range errs {
+			if errors.Is(spaceErr, ErrNotFound) {
+				delete(pending, id)
+			}
+		}
+
+		for _, s := range spaces {
+			switch s.State {
+			case SpaceLive:
+				select {
+				case live <- s:
+					delete(pending, s.ID)
+				case <-ctx.Done():
+					return
+				}
+			case SpaceEnded:
+				delete(pending, s.ID)
+			}
+		}
+
+		if len(pending) == 0 || sleep(ctx, interval) != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package twitter
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSearchSpaces(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.URL.Path, "/2/spaces/search")
+		equals(r.URL.Query().Get("query"), "golang")
+		equals(r.URL.Query().Get("state"), SpaceLive)
+		w.Write([]byte(`{"data": [{"id": "s1", "title": "Go news", "state": "live", "participant_count": 12,
+				"creator_id": "1", "host_ids": ["1"], "speaker_ids": ["2", "3"]}],
+			"includes": {"users": [{"id": "1", "username": "host"}, {"id": "2", "username": "speaker"}]}}`))
+	})
+
+	spaces, err := tw.SearchSpaces("golang", SpaceLive)
+	equals(err, nil)
+	equals(len(spaces), 1)
+	equals(spaces[0].ParticipantCount, 12)
+	equals(spaces[0].Creator.Handle, "host")
+	equals(spaces[0].Hosts[0].Handle, "host")
+	// unresolved users are left out
+	equals(len(spaces[0].Speakers), 1)
+	equals(spaces[0].Speakers[0].Handle, "speaker")
+}
+
+func TestWatchSpaces(t *testing.T) {
+
+	var mutex sync.Mutex
+	polls := 0
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		polls++
+		equals(r.URL.Path, "/2/spaces")
+
+		switch polls {
+		case 1:
+			w.Write([]byte(`{"data": [{"id": "s1", "state": "scheduled"}, {"id": "s2", "state": "scheduled"}]}`))
+		case 2:
+			w.Write([]byte(`{"data": [{"id": "s1", "state": "live"}, {"id": "s2", "state": "ended"}]}`))
+		default:
+			t.Error("no watched space left, polling should have stopped")
+		}
+	})
+
+	watcher := tw.WatchSpaces(context.Background(), []string{"s1", "s2"}, time.Millisecond)
+	space := <-watcher.Live
+	equals(space.ID, "s1")
+
+	_, open := <-watcher.Live
+	equals(open, false)
+}
+
+func TestWatchSpacesNotFound(t *testing.T) {
+
+	var mutex sync.Mutex
+	polls := 0
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		polls++
+
+		switch polls {
+		case 1:
+			w.Write([]byte(`{"data": [{"id": "s1", "state": "scheduled"}], "errors": [{"resource_id": "gone",
+				"title": "Not Found Error", "type": "https://api.twitter.com/2/problems/resource-not-found"}]}`))
+		case 2:
+			equals(r.URL.Query().Get("ids"), "s1")
+			w.Write([]byte(`{"data": [{"id": "s1", "state": "live"}]}`))
+		default:
+			t.Error("no watched space left, polling should have stopped")
+		}
+	})
+
+	watcher := tw.WatchSpaces(context.Background(), []string{"s1", "gone"}, time.Millisecond)
+	equals((<-watcher.Live).ID, "s1")
+
+	_, open := <-watcher.Live
+	equals(open, false)
+}
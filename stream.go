@@ -63,8 +63,12 @@ func (tw *Client) SubscribeStream(rule StreamRule) StreamSubscription {
 }
 
 // UnsubscribeStream removes the subscriber from the streamSubscribers slice and
-// closes their channels
+// closes their channels. Subscriptions created by SubscribePolling stop polling instead
 func (tw *Client) UnsubscribeStream(subToRemove StreamSubscription) {
+	if tw.unsubscribePolling(subToRemove) {
+		return
+	}
+
 	tw.Lock()
 	defer tw.Unlock()
 
@@ -129,7 +133,7 @@ func (tw *Client) stream() {
 	defer tw.logger.Println("stop streaming")
 	defer func() { tw.streaming = false }()
 
-	reqURL := fmt.Sprintf("%s/tweets/search/stream?%s", apiRoot, expansionsAndFields)
+	reqURL := fmt.Sprintf("%s/tweets/search/stream?%s", apiRoot, tw.fields(nil).Query())
 
 	ctx, cancelRequest := context.WithCancel(context.Background())
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
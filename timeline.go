@@ -0,0 +1,232 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimelineOptions filter and paginate timeline requests. The zero value requests the first page
+// with twitters defaults
+type TimelineOptions struct {
+	MaxResults      int    // number of tweets per page, between 5 and 100
+	PaginationToken string // NextToken of the previous TimelinePage
+	ExcludeRetweets bool   // not supported by GetUserMentions
+	ExcludeReplies  bool   // not supported by GetUserMentions
+	StartTime       time.Time
+	EndTime         time.Time
+	SinceID         string // only return tweets newer than this ID
+	UntilID         string // only return tweets older than this ID
+	Fields          *Fields
+}
+
+// TimelinePage is one page of tweets returned by a timeline endpoint
+type TimelinePage struct {
+	Tweets    []Tweet
+	NewestID  string
+	OldestID  string
+	NextToken string // pass as TimelineOptions.PaginationToken to get the next page, empty on the last page
+}
+
+// GetUserTweets calls the /2/users/:id/tweets endpoint and returns the tweets of the user,
+// newest first
+func (tw *Client) GetUserTweets(userID string, opts TimelineOptions) (page TimelinePage, err error) {
+	return tw.getTimeline(fmt.Sprintf("%s/users/%s/tweets", apiRoot, userID), opts)
+}
+
+// GetUserMentions calls the /2/users/:id/mentions endpoint and returns the tweets mentioning the user,
+// newest first
+func (tw *Client) GetUserMentions(userID string, opts TimelineOptions) (page TimelinePage, err error) {
+	opts.ExcludeRetweets = false
+	opts.ExcludeReplies = false
+	return tw.getTimeline(fmt.Sprintf("%s/users/%s/mentions", apiRoot, userID), opts)
+}
+
+// getTimeline requests a page of tweets from endpoint and converts them like search results
+func (tw *Client) getTimeline(endpoint string, opts TimelineOptions) (page TimelinePage, err error) {
+	uri := withQuery(endpoint, opts.values().Encode(), tw.fields(opts.Fields).Query())
+
+	var response searchResponse
+	err = tw.getJSON(uri, &response)
+	if err != nil {
+		return
+	}
+
+	return TimelinePage{
+		Tweets:    tweetsFromSearchResult(response),
+		NewestID:  response.Meta.NewestID,
+		OldestID:  response.Meta.OldestID,
+		NextToken: response.Meta.NextToken,
+	}, nil
+}
+
+// values returns the options as url query parameters
+func (opts TimelineOptions) values() url.Values {
+	params := url.Values{}
+	if opts.MaxResults > 0 {
+		params.Set("max_results", strconv.Itoa(opts.MaxResults))
+	}
+	if opts.PaginationToken != "" {
+		params.Set("pagination_token", opts.PaginationToken)
+	}
+
+	var exclude []string
+	if opts.ExcludeRetweets {
+		exclude = append(exclude, "retweets")
+	}
+	if opts.ExcludeReplies {
+		exclude = append(exclude, "replies")
+	}
+	if len(exclude) > 0 {
+		params.Set("exclude", strings.Join(exclude, ","))
+	}
+
+	if !opts.StartTime.IsZero() {
+		params.Set("start_time", opts.StartTime.UTC().Format(time.RFC3339))
+	}
+	if !opts.EndTime.IsZero() {
+		params.Set("end_time", opts.EndTime.UTC().Format(time.RFC3339))
+	}
+	if opts.SinceID != "" {
+		params.Set("since_id", opts.SinceID)
+	}
+	if opts.UntilID != "" {
+		params.Set("until_id", opts.UntilID)
+	}
+	return params
+}
+
+// GetHomeTimeline calls the /2/users/:id/timelines/reverse_chronological endpoint and returns the tweets
+// of the authenticated user and the accounts they follow, newest first.
+// It requires user context authentication, see NewWithAuth
+func (tw *Client) GetHomeTimeline(opts TimelineOptions) (TimelinePage, error) {
+	userID, err := tw.authenticatedUserID()
+	if err != nil {
+		return TimelinePage{}, err
+	}
+	return tw.getTimeline(fmt.Sprintf("%s/users/%s/timelines/reverse_chronological", apiRoot, userID), opts)
+}
+
+// TimelineWatcher contains a channel Tweets which receives new tweets of a polled timeline
+type TimelineWatcher struct {
+	Tweets chan Tweet
+	cancel context.CancelFunc
+}
+
+// Stop ends polling and closes Tweets
+func (w *TimelineWatcher) Stop() {
+	w.cancel()
+}
+
+// WatchHomeTimeline polls the home timeline every interval and sends tweets that were posted after the
+// watcher was started to TimelineWatcher.Tweets, oldest first. opts can be used to exclude retweets and
+// replies or select fields.
+// Polling stops when ctx is done or Stop is called, after which Tweets is closed
+func (tw *Client) WatchHomeTimeline(ctx context.Context, opts TimelineOptions, interval time.Duration) *TimelineWatcher {
+	ctx, cancel := context.WithCancel(ctx)
+	watcher := &TimelineWatcher{Tweets: make(chan Tweet), cancel: cancel}
+	go tw.watchTimeline(ctx, tw.GetHomeTimeline, opts, interval, watcher.Tweets)
+	return watcher
+}
+
+// watchTimeline calls fetch every interval with the newest ID seen as since_id and sends the new tweets
+// to out, oldest first. Tweets that were already sent are skipped.
+// The first call only determines the newest ID. out is closed when ctx is done
+func (tw *Client) watchTimeline(ctx context.Context, fetch func(TimelineOptions) (TimelinePage, error),
+	opts TimelineOptions, interval time.Duration, out chan<- Tweet) {
+	defer close(out)
+
+	opts.PaginationToken = ""
+	opts.StartTime = time.Time{}
+	opts.EndTime = time.Time{}
+	opts.UntilID = ""
+	started := opts.SinceID != ""
+	seen := newRecentIDs(maxRecentIDs)
+
+	for {
+		tweets, newestID, err := collectSince(fetch, opts)
+
+		var rateLimitErr *RateLimitError
+		switch {
+		case errors.As(err, &rateLimitErr):
+			if rateLimitErr.Wait(ctx) != nil {
+				return
+			}
+			continue
+		case err != nil:
+			tw.logger.Printf("failed to poll timeline: %s", err)
+		case !started:
+			started = true
+			opts.SinceID = newestID
+		default:
+			for i := len(tweets) - 1; i >= 0; i-- {
+				if seen.contains(tweets[i].ID) {
+					continue
+				}
+				select {
+				case out <- tweets[i]:
+					seen.add(tweets[i].ID)
+				case <-ctx.Done():
+					return
+				}
+			}
+			if newerID(newestID, opts.SinceID) {
+				opts.SinceID = newestID
+			}
+		}
+
+		if sleep(ctx, interval) != nil {
+			return
+		}
+	}
+}
+
+// collectSince fetches all pages of tweets newer than opts.SinceID, newest first
+func collectSince(fetch func(TimelineOptions) (TimelinePage, error), opts TimelineOptions) (tweets []Tweet, newestID string, err error) {
+	for {
+		page, err := fetch(opts)
+		if err != nil {
+			return nil, "", err
+		}
+		if newestID == "" {
+			newestID = page.NewestID
+		}
+		tweets = append(tweets, page.Tweets...)
+
+		// without a since_id only the newest page is of interest
+		if page.NextToken == "" || opts.SinceID == "" {
+			return tweets, newestID, nil
+		}
+		opts.PaginationToken = page.NextToken
+	}
+}
+
+// maxRecentIDs is the number of delivered tweet IDs a watcher remembers to skip duplicates
+const maxRecentIDs = 1000
+
+// recentIDs remembers the last added IDs
+type recentIDs struct {
+	ids   []string
+	index map[string]bool
+	next  int
+}
+
+func newRecentIDs(size int) *recentIDs {
+	return &recentIDs{ids: make([]string, size), index: make(map[string]bool, size)}
+}
+
+func (r *recentIDs) contains(id string) bool {
+	return r.index[id]
+}
+
+// add stores the ID, forgetting the oldest one if the buffer is full
+func (r *recentIDs) add(id string) {
+	delete(r.index, r.ids[r.next])
+	r.ids[r.next] = id
+	r.index[id] = true
+	r.next = (r.next + 1) % len(r.ids)
+}
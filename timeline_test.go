@@ -0,0 +1,104 @@
+package twitter
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetUserTweets(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.URL.Path, "/2/users/42/tweets")
+		query := r.URL.Query()
+		equals(query.Get("exclude"), "retweets,replies")
+		equals(query.Get("start_time"), "2022-01-01T00:00:00Z")
+		equals(query.Get("max_results"), "5")
+
+		if query.Get("pagination_token") == "" {
+			w.Write([]byte(`{"data": [{"id": "2", "text": "second"}, {"id": "1", "text": "first"}],
+				"meta": {"newest_id": "2", "oldest_id": "1", "result_count": 2, "next_token": "page2"}}`))
+			return
+		}
+		equals(query.Get("pagination_token"), "page2")
+		w.Write([]byte(`{"meta": {"result_count": 0}}`))
+	})
+
+	opts := TimelineOptions{
+		MaxResults:      5,
+		ExcludeRetweets: true,
+		ExcludeReplies:  true,
+		StartTime:       time.Date(2022, 1, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600)),
+	}
+	page, err := tw.GetUserTweets("42", opts)
+	equals(err, nil)
+	equals(len(page.Tweets), 2)
+	equals(page.Tweets[0].Text, "second")
+	equals(page.NewestID, "2")
+	equals(page.NextToken, "page2")
+
+	opts.PaginationToken = page.NextToken
+	page, err = tw.GetUserTweets("42", opts)
+	equals(err, nil)
+	equals(len(page.Tweets), 0)
+	equals(page.NextToken, "")
+}
+
+func TestGetUserMentions(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.URL.Path, "/2/users/42/mentions")
+		equals(r.URL.Query().Get("exclude"), "")
+		equals(r.URL.Query().Get("since_id"), "100")
+		w.Write([]byte(`{"data": [{"id": "101", "text": "@gopher hi"}], "meta": {"result_count": 1}}`))
+	})
+
+	page, err := tw.GetUserMentions("42", TimelineOptions{SinceID: "100", ExcludeReplies: true})
+	equals(err, nil)
+	equals(page.Tweets[0].ID, "101")
+}
+
+func TestWatchHomeTimeline(t *testing.T) {
+
+	var mutex sync.Mutex
+	caughtUp := make(chan string, 1)
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.URL.Path == "/2/users/me" {
+			w.Write([]byte(`{"data": {"id": "42"}}`))
+			return
+		}
+		equals(r.URL.Path, "/2/users/42/timelines/reverse_chronological")
+		query := r.URL.Query()
+
+		switch {
+		case query.Get("since_id") == "":
+			w.Write([]byte(`{"data": [{"id": "10", "text": "old"}], "meta": {"newest_id": "10"}}`))
+		case query.Get("since_id") == "10" && query.Get("pagination_token") == "":
+			w.Write([]byte(`{"data": [{"id": "13", "text": "third"}, {"id": "12", "text": "second"}],
+				"meta": {"newest_id": "13", "next_token": "p2"}}`))
+		case query.Get("pagination_token") == "p2":
+			w.Write([]byte(`{"data": [{"id": "11", "text": "first"}], "meta": {"newest_id": "11"}}`))
+		default:
+			select {
+			case caughtUp <- query.Get("since_id"):
+			default:
+			}
+			w.Write([]byte(`{"meta": {"result_count": 0}}`))
+		}
+	})
+
+	watcher := tw.WatchHomeTimeline(context.Background(), TimelineOptions{}, time.Millisecond)
+	equals((<-watcher.Tweets).Text, "first")
+	equals((<-watcher.Tweets).Text, "second")
+	equals((<-watcher.Tweets).Text, "third")
+	equals(<-caughtUp, "13")
+
+	watcher.Stop()
+	for range watcher.Tweets {
+	}
+}
@@ -0,0 +1,73 @@
+package twitter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxLookupIDs is the maximum number of IDs twitter accepts in a single lookup request
+const maxLookupIDs = 100
+
+// GetTweet calls the /2/tweets/:id endpoint and returns the tweet.
+// Deleted tweets and tweets of protected users are reported as an *APIError
+func (tw *Client) GetTweet(id string) (tweet Tweet, err error) {
+	return tw.GetTweetWithFields(nil, id)
+}
+
+// GetTweetWithFields works like GetTweet but requests the given fields instead of the Clients default
+func (tw *Client) GetTweetWithFields(fields *Fields, id string) (tweet Tweet, err error) {
+	uri := fmt.Sprintf("%s/tweets/%s?%s", apiRoot, id, tw.fields(fields).Query())
+
+	var response tweetResponse
+	err = tw.getJSON(uri, &response)
+	if err != nil {
+		return
+	}
+	if response.Tweet.ID == "" && len(response.Errors) > 0 {
+		return tweet, &response.Errors[0]
+	}
+
+	return convertToTweet(response.Tweet, response.Includes, nil), nil
+}
+
+// GetTweets calls the /2/tweets endpoint and returns the tweets with the given IDs, split into
+// requests of 100 IDs.
+// Tweets that could not be retrieved, e.g. because they were deleted, are left out and reported in errs
+// by their ID. err is only set if a request failed as a whole
+func (tw *Client) GetTweets(ids []string) (tweets []Tweet, errs map[string]error, err error) {
+	return tw.GetTweetsWithFields(nil, ids)
+}
+
+// GetTweetsWithFields works like GetTweets but requests the given fields instead of the Clients default
+func (tw *Client) GetTweetsWithFields(fields *Fields, ids []string) (tweets []Tweet, errs map[string]error, err error) {
+	errs = make(map[string]error)
+
+	for _, chunk := range chunkIDs(ids, maxLookupIDs) {
+		uri := fmt.Sprintf("%s/tweets?ids=%s&%s", apiRoot, strings.Join(chunk, ","), tw.fields(fields).Query())
+
+		var response searchResponse
+		err = tw.getJSON(uri, &response)
+		if err != nil {
+			return
+		}
+
+		tweets = append(tweets, tweetsFromSearchResult(response)...)
+		for i := range response.Errors {
+			errs[response.Errors[i].ResourceID] = &response.Errors[i]
+		}
+	}
+	return tweets, errs, nil
+}
+
+// chunkIDs splits ids into slices of at most size elements
+func chunkIDs(ids []string, size int) [][]string {
+	var chunks [][]string
+	for len(ids) > size {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+	return chunks
+}
@@ -0,0 +1,95 @@
+package twitter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetTweet(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/2/tweets/1" {
+			w.Write([]byte(`{"data": {"id": "1", "text": "hello", "author_id": "9"},
+				"includes": {"users": [{"id": "9", "username": "nine"}]}}`))
+			return
+		}
+		w.Write([]byte(`{"errors": [{"value": "2", "detail": "Could not find tweet with id: [2].",
+			"title": "Not Found Error", "resource_type": "tweet", "parameter": "id", "resource_id": "2",
+			"type": "https://api.twitter.com/2/problems/resource-not-found"}]}`))
+	})
+
+	tweet, err := tw.GetTweet("1")
+	equals(err, nil)
+	equals(tweet.Text, "hello")
+	equals(tweet.Author.Handle, "nine")
+
+	_, err = tw.GetTweet("2")
+	equals(errors.Is(err, ErrNotFound), true)
+	equals(errors.Is(err, ErrNotAuthorized), false)
+}
+
+func TestGetTweets(t *testing.T) {
+
+	var requests int
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+
+		var data []string
+		for _, id := range ids {
+			if id != "protected" {
+				data = append(data, fmt.Sprintf(`{"id": "%s", "text": "tweet %s"}`, id, id))
+			}
+		}
+		fmt.Fprintf(w, `{"data": [%s]`, strings.Join(data, ","))
+		if len(data) < len(ids) {
+			w.Write([]byte(`, "errors": [{"resource_id": "protected", "title": "Authorization Error",
+				"type": "https://api.twitter.com/2/problems/not-authorized-for-resource"}]`))
+		}
+		w.Write([]byte("}"))
+	})
+
+	ids := make([]string, 250)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i)
+	}
+	ids[120] = "protected"
+
+	tweets, errs, err := tw.GetTweets(ids)
+	equals(err, nil)
+	equals(requests, 3)
+	equals(len(tweets), 249)
+	equals(tweets[120].ID, "121")
+	equals(len(errs), 1)
+	equals(errors.Is(errs["protected"], ErrNotAuthorized), true)
+}
+
+func TestGetTweetWithFields(t *testing.T) {
+
+	var query string
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("tweet.fields")
+		if r.URL.Path == "/2/tweets" {
+			w.Write([]byte(`{"data": [{"id": "1", "lang": "en"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "1", "lang": "en"}}`))
+	})
+	tw.Fields = &Fields{TweetFields: []string{"source"}}
+
+	tweet, err := tw.GetTweetWithFields(&Fields{TweetFields: []string{"lang"}}, "1")
+	equals(err, nil)
+	equals(query, "lang")
+	equals(tweet.Lang, "en")
+
+	_, _, err = tw.GetTweetsWithFields(&Fields{TweetFields: []string{"conversation_id"}}, []string{"1"})
+	equals(err, nil)
+	equals(query, "conversation_id")
+
+	_, err = tw.GetTweet("1")
+	equals(err, nil)
+	equals(query, "source")
+}
@@ -12,23 +12,49 @@ StopStream is called.
 package twitter
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
 	"sync"
+	"time"
 )
 
 const apiRoot = "https://api.twitter.com/2"
-const expansionsAndFields = "expansions=author_id,attachments.media_keys,attachments.poll_ids" +
-	"&tweet.fields=author_id,created_at,text,public_metrics,possibly_sensitive" +
-	"&user.fields=profile_image_url,verified" +
-	"&media.fields=type,url,media_key,preview_image_url"
+
+// User represents a Twitter user, e.g. the author of a tweet or the result of a profile lookup.
+// Which fields are set depends on the requested user.fields
+type User struct {
+	Name          string          `json:"name"`
+	Handle        string          `json:"handle"`
+	Picture       string          `json:"picture"`
+	Verified      bool            `json:"verified"`
+	ID            string          `json:"id"`
+	Description   string          `json:"description,omitempty"`
+	Metrics       *AccountMetrics `json:"metrics,omitempty"` // only set if public_metrics were requested
+	CreatedAt     string          `json:"createdAt,omitempty"`
+	Location      string          `json:"location,omitempty"`
+	URL           string          `json:"url,omitempty"`
+	Protected     bool            `json:"protected,omitempty"`
+	PinnedTweetID string          `json:"pinnedTweetID,omitempty"`
+}
+
+// AccountMetrics count the followers, followed accounts and tweets of a User
+type AccountMetrics struct {
+	Followers int `json:"followers"`
+	Following int `json:"following"`
+	Tweets    int `json:"tweets"`
+}
 
 // Author represents a Twitter user who wrote a tweet
+//
+// Deprecated: Tweet.Author is a User now. Use User.Author to convert
 type Author struct {
 	Name     string `json:"name"`
 	Handle   string `json:"handle"`
@@ -37,54 +63,132 @@ type Author struct {
 	ID       string `json:"id"`
 }
 
+// Author converts the user into the fields of the former Author type
+func (u User) Author() Author {
+	return Author{
+		Name:     u.Name,
+		Handle:   u.Handle,
+		Picture:  u.Picture,
+		Verified: u.Verified,
+		ID:       u.ID,
+	}
+}
+
+// User converts the Author into a User
+func (a Author) User() User {
+	return User{
+		Name:     a.Name,
+		Handle:   a.Handle,
+		Picture:  a.Picture,
+		Verified: a.Verified,
+		ID:       a.ID,
+	}
+}
+
 // Tweet represents a tweet with all relevant metadata
 type Tweet struct {
-	ID              string   `json:"id"`
-	Text            string   `json:"text"`
-	Author          Author   `json:"author"`
-	Created         string   `json:"created"`
-	Images          []string `json:"images"`
-	Retweets        int      `json:"retweets"`
-	Replies         int      `json:"replies"`
-	Likes           int      `json:"likes"`
-	Quotes          int      `json:"quotes"`
-	RuleIDs         []string
+	ID       string   `json:"id"`
+	Text     string   `json:"text"`
+	Author   User     `json:"author"`
+	Created  string   `json:"created"`
+	Images   []string `json:"images"` // photo URLs, derived from Media
+	Retweets int      `json:"retweets"`
+	Replies  int      `json:"replies"`
+	Likes    int      `json:"likes"`
+	Quotes   int      `json:"quotes"`
+	RuleIDs  []string
+	// HasVideo and VideoPreviewURL are derived from Media and describe the first video attached
 	HasVideo        bool         `json:"hasVideo"`
 	VideoPreviewURL string       `json:"videoPreviewURL"`
 	Sensitive       bool         `json:"sensitive"`
-	Poll            []PollOption `json:"poll,omitempty"`
+	Poll            []PollOption `json:"poll,omitempty"` // options of PollDetails
+	PollDetails     *Poll        `json:"pollDetails,omitempty"`
+	Media           []MediaItem  `json:"media,omitempty"`
+	Place           *Place       `json:"place,omitempty"`
+	Coordinates     *Coordinates `json:"coordinates,omitempty"` // exact location, only set if the user shared it
+	Source          string       `json:"source,omitempty"`
+	ReplySettings   string       `json:"replySettings,omitempty"`
+	ConversationID  string       `json:"conversationID,omitempty"`
+	// EditHistoryTweetIDs lists the IDs of all versions of the tweet, from the original to the latest
+	EditHistoryTweetIDs []string            `json:"editHistoryTweetIDs,omitempty"`
+	EditControls        *EditControls       `json:"editControls,omitempty"`
+	Lang                string              `json:"lang,omitempty"`
+	ContextAnnotations  []ContextAnnotation `json:"contextAnnotations,omitempty"`
+}
+
+// Media types used by MediaItem.Type
+const (
+	MediaTypePhoto       = "photo"
+	MediaTypeVideo       = "video"
+	MediaTypeAnimatedGIF = "animated_gif"
+)
+
+// MediaItem represents a photo, video or animated GIF attached to a tweet
+type MediaItem struct {
+	Key        string         `json:"key"`
+	Type       string         `json:"type"`
+	URL        string         `json:"url,omitempty"`        // only set for photos
+	PreviewURL string         `json:"previewURL,omitempty"` // only set for videos and GIFs
+	Width      int            `json:"width"`
+	Height     int            `json:"height"`
+	DurationMs int            `json:"durationMs,omitempty"`
+	AltText    string         `json:"altText,omitempty"`
+	Views      int            `json:"views,omitempty"`
+	Variants   []VideoVariant `json:"variants,omitempty"` // only set for videos and GIFs
 }
 
 // Client provides access to (some) twitter api endpoints
 type Client struct {
 	Token                  string
+	Auth                   Authenticator // used instead of Token if set
+	HTTPClient             *http.Client  // used for all requests if set
+	Fields                 *Fields       // requested for tweets if set, otherwise DefaultFields are used
+	UserFields             []string      // user.fields requested by user lookups if set, otherwise every field of User
 	streamSubscribers      []StreamSubscription
 	streaming              bool
 	stopStreamChan         chan bool
 	StreamedTweets         chan Tweet // every Tweet received from the streaming endpoint, regardless of matching rules
 	EnableAllTweetsChannel bool
 	logger                 *log.Logger
+	myID                   string                            // ID of the authenticated user, cached by authenticatedUserID
+	myIDLock               sync.Mutex                        // guards myID, separate from the Client lock held while streaming
+	pollers                map[chan Tweet]context.CancelFunc // polling subscriptions by their Tweets channel
 	sync.Mutex
 }
 
 // tweet represents how the twitter api describes tweets
 type tweet struct {
-	AuthorID    string `json:"author_id"`
-	Text        string
-	ID          string
-	CreatedAt   string      `json:"created_at"`
-	Metrics     metrics     `json:"public_metrics"`
-	Attachments attachments `json:"attachments"`
-	Sensitive   bool        `json:"possibly_sensitive"`
+	AuthorID            string `json:"author_id"`
+	Text                string
+	ID                  string
+	CreatedAt           string              `json:"created_at"`
+	Metrics             metrics             `json:"public_metrics"`
+	Attachments         attachments         `json:"attachments"`
+	Sensitive           bool                `json:"possibly_sensitive"`
+	Geo                 geo                 `json:"geo"`
+	Source              string              `json:"source"`
+	ReplySettings       string              `json:"reply_settings"`
+	ConversationID      string              `json:"conversation_id"`
+	EditHistoryTweetIDs []string            `json:"edit_history_tweet_ids"`
+	EditControls        *editControls       `json:"edit_controls"`
+	Lang                string              `json:"lang"`
+	ContextAnnotations  []ContextAnnotation `json:"context_annotations"`
 }
 
 // user is a twitter user as given by the api
 type user struct {
-	ID       string `json:"id"`
-	Name     string
-	Handle   string `json:"username"`
-	Picture  string `json:"profile_image_url"`
-	Verified bool
+	ID            string `json:"id"`
+	Name          string
+	Handle        string `json:"username"`
+	Picture       string `json:"profile_image_url"`
+	Verified      bool
+	Description   string       `json:"description"`
+	Metrics       *UserMetrics `json:"public_metrics"`
+	CreatedAt     string       `json:"created_at"`
+	Location      string       `json:"location"`
+	URL           string       `json:"url"`
+	Protected     bool         `json:"protected"`
+	PinnedTweetID string       `json:"pinned_tweet_id"`
 }
 
 // metrics describes user interacting with a tweet
@@ -107,22 +211,45 @@ type media struct {
 	Type                 string `json:"type"`
 	URL                  string `json:"url"`
 	VideoPreviewImageURL string `json:"preview_image_url"`
+	Width                int    `json:"width"`
+	Height               int    `json:"height"`
+	DurationMs           int    `json:"duration_ms"`
+	AltText              string `json:"alt_text"`
+	Metrics              struct {
+		Views int `json:"view_count"`
+	} `json:"public_metrics"`
+	Variants []videoVariant `json:"variants"`
 }
 
 // includes holds metadata for tweets like media and user(s)
 type includes struct {
-	Users []user
-	Media []media
-	Polls []struct {
-		Options []PollOption `json:"options"`
-		ID      string       `json:"id"`
-	} `json:"polls"`
+	Users  []user
+	Media  []media
+	Polls  []poll  `json:"polls"`
+	Places []place `json:"places"`
 }
 
 // searchResponse represents the data returned by twitters search api
 type searchResponse struct {
-	Tweets   []tweet  `json:"data"`
-	Includes includes `json:"includes"`
+	Tweets   []tweet    `json:"data"`
+	Includes includes   `json:"includes"`
+	Errors   []APIError `json:"errors"`
+	Meta     meta       `json:"meta"`
+}
+
+// meta holds pagination information of list responses
+type meta struct {
+	ResultCount int    `json:"result_count"`
+	NewestID    string `json:"newest_id"`
+	OldestID    string `json:"oldest_id"`
+	NextToken   string `json:"next_token"`
+}
+
+// tweetResponse represents the data returned when looking up a single tweet
+type tweetResponse struct {
+	Tweet    tweet      `json:"data"`
+	Includes includes   `json:"includes"`
+	Errors   []APIError `json:"errors"`
 }
 
 // PollOption represents a possible answer in a Poll
@@ -134,8 +261,20 @@ type PollOption struct {
 
 // New creates a new Client with the given token
 func New(token string) Client {
+	return newClient(token, nil)
+}
+
+// NewWithAuth creates a new Client that authenticates its requests using auth,
+// e.g. to act on behalf of a user with OAuth1 or OAuth2
+func NewWithAuth(auth Authenticator) Client {
+	return newClient("", auth)
+}
+
+// newClient creates a Client with either a bearer token or an Authenticator
+func newClient(token string, auth Authenticator) Client {
 	return Client{
 		Token:          token,
+		Auth:           auth,
 		logger:         log.New(os.Stdout, "[twitter] ", log.Ldate|log.Ltime|log.Lmsgprefix|log.Lshortfile),
 		stopStreamChan: make(chan bool),
 		StreamedTweets: make(chan Tweet),
@@ -147,16 +286,23 @@ const (
 	ImageFilter = "has:images"
 	// ExcludeRetweetsFilter excludes retweets if added to a stream rule
 	ExcludeRetweetsFilter = "-is:retweet"
+	// GeoFilter searches only for tweets that have a place or coordinates attached if added to a stream rule
+	GeoFilter = "has:geo"
 )
 
-// authenticatedTwitterRequest adds an authentication token to the request header,
+// authenticatedTwitterRequest authenticates the request using the Clients Authenticator or bearer token,
 // sends the request and returns the response
 func (tw *Client) authenticatedTwitterRequest(request *http.Request) (response *http.Response, err error) {
-	request.Header.Set("Authorization", "Bearer "+tw.Token)
-
-	client := http.Client{}
+	auth := tw.Auth
+	if auth == nil {
+		auth = BearerToken(tw.Token)
+	}
+	err = auth.Authenticate(request)
+	if err != nil {
+		return
+	}
 
-	httpResponse, err := client.Do(request)
+	httpResponse, err := tw.httpClient().Do(request)
 	if err != nil {
 		return
 	}
@@ -164,10 +310,61 @@ func (tw *Client) authenticatedTwitterRequest(request *http.Request) (response *
 	return httpResponse, nil
 }
 
+// httpClient returns the Clients HTTPClient or a default one
+func (tw *Client) httpClient() *http.Client {
+	if tw.HTTPClient != nil {
+		return tw.HTTPClient
+	}
+	return &http.Client{}
+}
+
+// withQuery appends the non-empty query strings to endpoint
+func withQuery(endpoint string, queries ...string) string {
+	var nonEmpty []string
+	for _, query := range queries {
+		if query != "" {
+			nonEmpty = append(nonEmpty, query)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return endpoint
+	}
+	return endpoint + "?" + strings.Join(nonEmpty, "&")
+}
+
+// getJSON sends an authenticated GET request to uri and decodes the json response into v
+func (tw *Client) getJSON(uri string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return err
+	}
+
+	result, err := tw.authenticatedTwitterRequest(req)
+	if err != nil {
+		return err
+	}
+	defer result.Body.Close()
+
+	if result.StatusCode == http.StatusTooManyRequests {
+		return rateLimitError(result)
+	}
+	if result.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(result.Body)
+		return fmt.Errorf("request failed with status %s. Response: %s", result.Status, string(data))
+	}
+
+	return json.NewDecoder(result.Body).Decode(v)
+}
+
 // SearchRecent sends a query to the /2/tweets/search/recent Twitter API
 // and returns the received tweets.
 // Options accepts several strings for keywords or options like ImageFilter
 func (tw *Client) SearchRecent(options ...string) (tweets []Tweet, err error) {
+	return tw.SearchRecentWithFields(nil, options...)
+}
+
+// SearchRecentWithFields works like SearchRecent but requests the given fields instead of the Clients default
+func (tw *Client) SearchRecentWithFields(fields *Fields, options ...string) (tweets []Tweet, err error) {
 
 	queryBuilder := strings.Builder{}
 
@@ -176,7 +373,7 @@ func (tw *Client) SearchRecent(options ...string) (tweets []Tweet, err error) {
 		queryBuilder.WriteString(" ")
 	}
 	escapedQuery := url.QueryEscape(queryBuilder.String()) // https://stackoverflow.com/questions/58419348/is-there-a-urlencode-function-in-golang
-	uri := fmt.Sprintf("%s/tweets/search/recent?query=%s&max_results=10&%s", apiRoot, escapedQuery, expansionsAndFields)
+	uri := fmt.Sprintf("%s/tweets/search/recent?query=%s&max_results=10&%s", apiRoot, escapedQuery, tw.fields(fields).Query())
 
 	req, err := http.NewRequest(http.MethodGet, uri, nil)
 	if err != nil {
@@ -198,6 +395,43 @@ func (tw *Client) SearchRecent(options ...string) (tweets []Tweet, err error) {
 	return tweets, nil
 }
 
+// sendJSON sends body as json to uri using an authenticated request with the given method
+// and decodes the json response into v. body may be nil
+func (tw *Client) sendJSON(method, uri string, body, v interface{}) error {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, uri, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	result, err := tw.authenticatedTwitterRequest(req)
+	if err != nil {
+		return err
+	}
+	defer result.Body.Close()
+
+	if result.StatusCode == http.StatusTooManyRequests {
+		return rateLimitError(result)
+	}
+	if result.StatusCode != http.StatusOK && result.StatusCode != http.StatusCreated {
+		data, _ := ioutil.ReadAll(result.Body)
+		return fmt.Errorf("request failed with status %s. Response: %s", result.Status, string(data))
+	}
+
+	return json.NewDecoder(result.Body).Decode(v)
+}
+
 // tweetsFromSearchResult converts twitters searchResponse.Tweets into a slice of Tweet
 func tweetsFromSearchResult(response searchResponse) []Tweet {
 	tweets := make([]Tweet, len(response.Tweets))
@@ -211,44 +445,71 @@ func tweetsFromSearchResult(response searchResponse) []Tweet {
 // convertToTweet merges twitters tweet and includes metadata
 // into a Tweet object
 func convertToTweet(tweet tweet, incl includes, matches *[]StreamRule) Tweet {
-	var author Author
+	var author User
 
 	for _, user := range incl.Users {
 		if user.ID == tweet.AuthorID {
-			author.Name = user.Name
-			author.Handle = user.Handle
-			author.Picture = user.Picture
-			author.Verified = user.Verified
-			author.ID = user.ID
+			author = convertToUser(user)
 			break
 		}
 	}
 
+	var mediaItems []MediaItem
 	var images []string
 	var hasVideo bool
 	var videoPreview string
 	for _, mediaKey := range tweet.Attachments.MediaKeys {
 		for _, mediaItem := range incl.Media {
 			if mediaKey == mediaItem.MediaKey {
+				mediaItems = append(mediaItems, convertToMediaItem(mediaItem))
 
 				switch mediaItem.Type {
-				case "photo":
+				case MediaTypePhoto:
 					images = append(images, mediaItem.URL)
-				case "video":
-					hasVideo = true
-					videoPreview = mediaItem.VideoPreviewImageURL
+				case MediaTypeVideo:
+					if !hasVideo {
+						hasVideo = true
+						videoPreview = mediaItem.VideoPreviewImageURL
+					}
 				}
+				break
 			}
 		}
 	}
 
 	var pollOptions []PollOption
+	var pollDetails *Poll
 	for _, pollID := range tweet.Attachments.PollIDs {
 		for _, poll := range incl.Polls {
 			if poll.ID == pollID {
-				pollOptions = poll.Options
+				converted := convertToPoll(poll)
+				pollDetails = &converted
+				pollOptions = converted.Options
+				break
 			}
 		}
+		if pollDetails != nil {
+			break
+		}
+	}
+
+	var tweetPlace *Place
+	if tweet.Geo.PlaceID != "" {
+		for _, place := range incl.Places {
+			if place.ID == tweet.Geo.PlaceID {
+				converted := convertToPlace(place)
+				tweetPlace = &converted
+				break
+			}
+		}
+	}
+
+	var coordinates *Coordinates
+	if tweet.Geo.Coordinates != nil && len(tweet.Geo.Coordinates.Coordinates) == 2 {
+		coordinates = &Coordinates{
+			Longitude: tweet.Geo.Coordinates.Coordinates[0],
+			Latitude:  tweet.Geo.Coordinates.Coordinates[1],
+		}
 	}
 
 	var rules []string
@@ -259,19 +520,77 @@ func convertToTweet(tweet tweet, incl includes, matches *[]StreamRule) Tweet {
 		}
 	}
 	return Tweet{
-		ID:              tweet.ID,
-		Text:            tweet.Text,
-		Author:          author,
-		Created:         tweet.CreatedAt,
-		Images:          images,
-		Retweets:        tweet.Metrics.Retweets,
-		Replies:         tweet.Metrics.Replies,
-		Likes:           tweet.Metrics.Likes,
-		Quotes:          tweet.Metrics.Quotes,
-		RuleIDs:         rules,
-		HasVideo:        hasVideo,
-		VideoPreviewURL: videoPreview,
-		Sensitive:       tweet.Sensitive,
-		Poll:            pollOptions,
+		ID:                  tweet.ID,
+		Text:                tweet.Text,
+		Author:              author,
+		Created:             tweet.CreatedAt,
+		Images:              images,
+		Retweets:            tweet.Metrics.Retweets,
+		Replies:             tweet.Metrics.Replies,
+		Likes:               tweet.Metrics.Likes,
+		Quotes:              tweet.Metrics.Quotes,
+		RuleIDs:             rules,
+		HasVideo:            hasVideo,
+		VideoPreviewURL:     videoPreview,
+		Sensitive:           tweet.Sensitive,
+		Poll:                pollOptions,
+		PollDetails:         pollDetails,
+		Media:               mediaItems,
+		Place:               tweetPlace,
+		Coordinates:         coordinates,
+		Source:              tweet.Source,
+		ReplySettings:       tweet.ReplySettings,
+		ConversationID:      tweet.ConversationID,
+		EditHistoryTweetIDs: tweet.EditHistoryTweetIDs,
+		EditControls:        convertToEditControls(tweet.EditControls),
+		Lang:                tweet.Lang,
+		ContextAnnotations:  tweet.ContextAnnotations,
+	}
+}
+
+// convertToUser converts twitters user object into a User
+func convertToUser(u user) User {
+	return User{
+		Name:          u.Name,
+		Handle:        u.Handle,
+		Picture:       u.Picture,
+		Verified:      u.Verified,
+		ID:            u.ID,
+		Description:   u.Description,
+		Metrics:       convertToAccountMetrics(u.Metrics),
+		CreatedAt:     u.CreatedAt,
+		Location:      u.Location,
+		URL:           u.URL,
+		Protected:     u.Protected,
+		PinnedTweetID: u.PinnedTweetID,
+	}
+}
+
+// convertToMediaItem converts twitters media object into a MediaItem
+func convertToMediaItem(m media) MediaItem {
+	return MediaItem{
+		Key:        m.MediaKey,
+		Type:       m.Type,
+		URL:        m.URL,
+		PreviewURL: m.VideoPreviewImageURL,
+		Width:      m.Width,
+		Height:     m.Height,
+		DurationMs: m.DurationMs,
+		AltText:    m.AltText,
+		Views:      m.Metrics.Views,
+		Variants:   convertToVideoVariants(m.Variants),
+	}
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
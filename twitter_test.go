@@ -2,7 +2,11 @@ package twitter
 
 import (
 	"bytes"
+	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 )
 
@@ -88,7 +92,7 @@ func TestConvertToTweet(t *testing.T) {
 	equals(niceTweet.Author.Handle, "@one")
 	equals(niceTweet.ID, "tweetid")
 	equals(niceTweet.HasVideo, false)
-	matches := []streamRuleInt{{ID: 123}}
+	matches := []StreamRule{{ID: "123"}}
 
 	tweetWithRule := convertToTweet(tweeet, incl, &matches)
 
@@ -125,6 +129,78 @@ func TestConvertToTweet(t *testing.T) {
 	equals(niceTweet.Author.Verified, true)
 }
 
+func TestConvertToTweetMedia(t *testing.T) {
+
+	tweeet := tweet{
+		ID:          "tweetid",
+		Attachments: attachments{MediaKeys: []string{"gif", "photo", "video1", "video2"}},
+	}
+	incl := includes{
+		Media: []media{
+			{MediaKey: "video2", Type: MediaTypeVideo, VideoPreviewImageURL: "https://example.org/preview2"},
+			{MediaKey: "photo", Type: MediaTypePhoto, URL: "https://example.org/photo.jpg", Width: 640, Height: 480, AltText: "a cat"},
+			{MediaKey: "gif", Type: MediaTypeAnimatedGIF, VideoPreviewImageURL: "https://example.org/gif.jpg"},
+			{MediaKey: "video1", Type: MediaTypeVideo, VideoPreviewImageURL: "https://example.org/preview1", DurationMs: 1500},
+		},
+	}
+	incl.Media[3].Metrics.Views = 42
+	incl.Media[3].Variants = []videoVariant{{Bitrate: 832000, ContentType: ContentTypeMP4, URL: "https://example.org/video1.mp4"}}
+
+	niceTweet := convertToTweet(tweeet, incl, nil)
+
+	equals(len(niceTweet.Media), 4)
+	equals(niceTweet.Media[0].Type, MediaTypeAnimatedGIF)
+	equals(niceTweet.Media[1].AltText, "a cat")
+	equals(niceTweet.Media[1].Width, 640)
+	equals(niceTweet.Media[2].DurationMs, 1500)
+	equals(niceTweet.Media[2].Views, 42)
+	equals(niceTweet.Media[2].Variants[0].Bitrate, 832000)
+	equals(niceTweet.Media[2].Variants[0].URL, "https://example.org/video1.mp4")
+	equals(niceTweet.Media[3].PreviewURL, "https://example.org/preview2")
+
+	// compatibility fields
+	equals(len(niceTweet.Images), 1)
+	equals(niceTweet.Images[0], "https://example.org/photo.jpg")
+	equals(niceTweet.HasVideo, true)
+	equals(niceTweet.VideoPreviewURL, "https://example.org/preview1")
+}
+
+// rewriteTransport sends every request to the test server instead of the original host
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (rt rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestHTTPClient returns an http.Client whose requests are answered by handler
+func newTestHTTPClient(t *testing.T, handler http.HandlerFunc) *http.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	return &http.Client{Transport: rewriteTransport{target}}
+}
+
+// newTestClient returns a Client whose requests are answered by handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	tw := New("token")
+	tw.HTTPClient = newTestHTTPClient(t, handler)
+	return &tw
+}
+
+// writeStreamedTweets answers a stream request with a tweet matching the rule for every ID.
+// Each tweet is written by the user with the ID "a<tweet ID>"
+func writeStreamedTweets(w http.ResponseWriter, ruleID string, tweetIDs ...string) {
+	for _, id := range tweetIDs {
+		fmt.Fprintf(w, `{"data": {"id": "%[1]s", "author_id": "a%[1]s"}, "includes": {"users": [{"id": "a%[1]s"}]}, `+
+			`"matching_rules": [{"id": "%[2]s"}]}`+"\n", id, ruleID)
+	}
+}
+
 // equals checks if the supplied values are equal.
 // If they are not, both values are logged and the program exits
 func equals(actual, expected interface{}) {
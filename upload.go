@@ -0,0 +1,289 @@
+package twitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	uploadURL         = "https://upload.twitter.com/1.1/media/upload.json"
+	mediaMetadataURL  = "https://upload.twitter.com/1.1/media/metadata/create.json"
+	defaultChunkSize  = 4 * 1024 * 1024
+	defaultRetries    = 3
+	defaultRetryDelay = time.Second
+)
+
+// Media categories for MediaUpload.Category
+const (
+	MediaCategoryImage = "tweet_image"
+	MediaCategoryGIF   = "tweet_gif"
+	MediaCategoryVideo = "tweet_video"
+)
+
+// MediaUpload describes a file to upload using UploadMedia
+type MediaUpload struct {
+	Reader    io.Reader
+	Size      int64
+	MediaType string // MIME type, e.g. image/png or video/mp4
+	Category  string // derived from MediaType if empty
+	AltText   string // description for screen readers, set after the upload if not empty
+}
+
+// MediaUploader uploads media in chunks using the v1.1 media/upload endpoint.
+// This requires user context authentication, see OAuth1. The zero value of the options uses sensible defaults
+type MediaUploader struct {
+	Client     *Client
+	ChunkSize  int           // bytes per APPEND request, defaults to 4 MB
+	Retries    int           // attempts per chunk, defaults to 3
+	RetryDelay time.Duration // wait time after the first failed attempt, doubled for each retry. Defaults to 1s
+}
+
+// uploadResponse is returned by the INIT, FINALIZE and STATUS commands
+type uploadResponse struct {
+	MediaID        string `json:"media_id_string"`
+	ProcessingInfo *struct {
+		State          string `json:"state"` // pending, in_progress, failed or succeeded
+		CheckAfterSecs int    `json:"check_after_secs"`
+		Error          *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"processing_info"`
+}
+
+// UploadMedia uploads the media using a MediaUploader with default options and returns the media ID,
+// which can be attached to a tweet using NewTweet.MediaIDs
+func (tw *Client) UploadMedia(ctx context.Context, upload MediaUpload) (mediaID string, err error) {
+	uploader := MediaUploader{Client: tw}
+	return uploader.Upload(ctx, upload)
+}
+
+// Upload runs the INIT, APPEND and FINALIZE commands, waits until twitter processed videos and GIFs and
+// sets the alt text. It returns the media ID
+func (u *MediaUploader) Upload(ctx context.Context, upload MediaUpload) (mediaID string, err error) {
+	category := upload.Category
+	if category == "" {
+		category = mediaCategory(upload.MediaType)
+	}
+
+	var initResponse uploadResponse
+	err = u.postForm(ctx, url.Values{
+		"command":        {"INIT"},
+		"total_bytes":    {strconv.FormatInt(upload.Size, 10)},
+		"media_type":     {upload.MediaType},
+		"media_category": {category},
+	}, &initResponse)
+	if err != nil {
+		return
+	}
+	mediaID = initResponse.MediaID
+
+	chunkSize := u.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+	chunk := make([]byte, chunkSize)
+	for segment := 0; ; segment++ {
+		n, readErr := io.ReadFull(upload.Reader, chunk)
+		if n > 0 {
+			err = u.appendChunk(ctx, mediaID, segment, chunk[:n])
+			if err != nil {
+				return
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return mediaID, readErr
+		}
+	}
+
+	var status uploadResponse
+	err = u.postForm(ctx, url.Values{"command": {"FINALIZE"}, "media_id": {mediaID}}, &status)
+	if err != nil {
+		return
+	}
+	err = u.waitForProcessing(ctx, mediaID, status)
+	if err != nil {
+		return
+	}
+
+	if upload.AltText != "" {
+		err = u.setAltText(ctx, mediaID, upload.AltText)
+	}
+	return
+}
+
+// appendChunk uploads a segment, retrying failed attempts with increasing delays.
+// Rate limits are waited out and requests rejected with a 4xx status are not retried
+func (u *MediaUploader) appendChunk(ctx context.Context, mediaID string, segment int, data []byte) (err error) {
+	retries := u.Retries
+	if retries <= 0 {
+		retries = defaultRetries
+	}
+	delay := u.RetryDelay
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+
+	for attempt := 0; attempt < retries; {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("command", "APPEND")
+		writer.WriteField("media_id", mediaID)
+		writer.WriteField("segment_index", strconv.Itoa(segment))
+		part, _ := writer.CreateFormFile("media", "media")
+		part.Write(data)
+		writer.Close()
+
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, body)
+		if err != nil {
+			return
+		}
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		err = u.do(req, nil)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+
+		var rateLimitErr *RateLimitError
+		var statusErr *uploadStatusError
+		switch {
+		case errors.As(err, &rateLimitErr):
+			// waiting for the reset does not use up an attempt
+			if waitErr := rateLimitErr.Wait(ctx); waitErr != nil {
+				return waitErr
+			}
+			continue
+		case errors.As(err, &statusErr) && statusErr.StatusCode < 500:
+			// retrying does not help with rejected requests
+			return fmt.Errorf("failed to upload segment %d: %w", segment, err)
+		}
+
+		attempt++
+		if attempt < retries {
+			if err := sleep(ctx, delay); err != nil {
+				return err
+			}
+			delay *= 2
+		}
+	}
+	return fmt.Errorf("failed to upload segment %d: %w", segment, err)
+}
+
+// waitForProcessing polls the STATUS command until twitter finished processing the media
+func (u *MediaUploader) waitForProcessing(ctx context.Context, mediaID string, status uploadResponse) error {
+	for status.ProcessingInfo != nil {
+		info := status.ProcessingInfo
+		switch info.State {
+		case "succeeded":
+			return nil
+		case "failed":
+			if info.Error != nil {
+				return fmt.Errorf("media processing failed: %s", info.Error.Message)
+			}
+			return fmt.Errorf("media processing failed")
+		}
+
+		if err := sleep(ctx, time.Duration(info.CheckAfterSecs)*time.Second); err != nil {
+			return err
+		}
+
+		params := url.Values{"command": {"STATUS"}, "media_id": {mediaID}}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, uploadURL+"?"+params.Encode(), nil)
+		if err != nil {
+			return err
+		}
+		status = uploadResponse{}
+		if err = u.do(req, &status); err != nil {
+			return err
+		}
+	}
+	// images are not processed asynchronously
+	return nil
+}
+
+// setAltText sets the description of the media
+func (u *MediaUploader) setAltText(ctx context.Context, mediaID, altText string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"media_id": mediaID,
+		"alt_text": map[string]string{"text": altText},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, mediaMetadataURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return u.do(req, nil)
+}
+
+// postForm sends a form encoded command to the upload endpoint
+func (u *MediaUploader) postForm(ctx context.Context, form url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return u.do(req, v)
+}
+
+// do sends an authenticated request and decodes the json response into v if it is not nil.
+// Unlike the v2 endpoints, the upload endpoint answers with various 2xx status codes and empty bodies
+func (u *MediaUploader) do(req *http.Request, v interface{}) error {
+	result, err := u.Client.authenticatedTwitterRequest(req)
+	if err != nil {
+		return err
+	}
+	defer result.Body.Close()
+
+	if result.StatusCode == http.StatusTooManyRequests {
+		return rateLimitError(result)
+	}
+	if result.StatusCode < 200 || result.StatusCode > 299 {
+		data, _ := ioutil.ReadAll(result.Body)
+		return &uploadStatusError{StatusCode: result.StatusCode, Status: result.Status, Response: string(data)}
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(result.Body).Decode(v)
+}
+
+// uploadStatusError is returned by MediaUploader.do for responses with an unexpected status code
+type uploadStatusError struct {
+	StatusCode int
+	Status     string
+	Response   string
+}
+
+func (e *uploadStatusError) Error() string {
+	return fmt.Sprintf("upload request failed with status %s. Response: %s", e.Status, e.Response)
+}
+
+// mediaCategory derives the upload category from the MIME type
+func mediaCategory(mediaType string) string {
+	switch {
+	case mediaType == "image/gif":
+		return MediaCategoryGIF
+	case strings.HasPrefix(mediaType, "video/"):
+		return MediaCategoryVideo
+	}
+	return MediaCategoryImage
+}
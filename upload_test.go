@@ -0,0 +1,115 @@
+package twitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestUploadMedia(t *testing.T) {
+
+	content := bytes.Repeat([]byte("0123456789"), 25)
+	var received []byte
+	var appendAttempts, statusRequests int
+	var altText string
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.Host, "upload.twitter.com")
+
+		if r.URL.Path == "/1.1/media/metadata/create.json" {
+			var body struct {
+				MediaID string `json:"media_id"`
+				AltText struct {
+					Text string `json:"text"`
+				} `json:"alt_text"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			equals(body.MediaID, "710511363345354753")
+			altText = body.AltText.Text
+			return
+		}
+
+		switch r.FormValue("command") {
+		case "INIT":
+			equals(r.FormValue("total_bytes"), "250")
+			equals(r.FormValue("media_category"), MediaCategoryVideo)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"media_id_string": "710511363345354753"}`))
+		case "APPEND":
+			appendAttempts++
+			// the first attempt of every chunk fails
+			if appendAttempts%2 == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			file, _, err := r.FormFile("media")
+			equals(err, nil)
+			data, _ := ioutil.ReadAll(file)
+			received = append(received, data...)
+			w.WriteHeader(http.StatusNoContent)
+		case "FINALIZE":
+			w.Write([]byte(`{"media_id_string": "710511363345354753",
+				"processing_info": {"state": "pending", "check_after_secs": 0}}`))
+		case "STATUS":
+			statusRequests++
+			w.Write([]byte(`{"media_id_string": "710511363345354753",
+				"processing_info": {"state": "succeeded"}}`))
+		}
+	})
+
+	uploader := MediaUploader{Client: tw, ChunkSize: 100, RetryDelay: time.Millisecond}
+	mediaID, err := uploader.Upload(context.Background(), MediaUpload{
+		Reader:    bytes.NewReader(content),
+		Size:      int64(len(content)),
+		MediaType: "video/mp4",
+		AltText:   "a short clip",
+	})
+	equals(err, nil)
+	equals(mediaID, "710511363345354753")
+	equals(appendAttempts, 6)
+	equals(received, content)
+	equals(statusRequests, 1)
+	equals(altText, "a short clip")
+}
+
+func TestUploadChunkErrors(t *testing.T) {
+
+	var appendAttempts int
+	var appendStatus []int
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("command") {
+		case "INIT":
+			w.Write([]byte(`{"media_id_string": "1"}`))
+		case "APPEND":
+			status := appendStatus[appendAttempts]
+			appendAttempts++
+			if status == http.StatusTooManyRequests {
+				w.Header().Set("x-rate-limit-reset", fmt.Sprint(time.Now().Unix()))
+			}
+			w.WriteHeader(status)
+		case "FINALIZE":
+			w.Write([]byte(`{"media_id_string": "1"}`))
+		}
+	})
+	uploader := MediaUploader{Client: tw, Retries: 1, RetryDelay: time.Millisecond}
+	upload := func() error {
+		_, err := uploader.Upload(context.Background(), MediaUpload{Reader: bytes.NewReader([]byte("data")), Size: 4, MediaType: "image/png"})
+		return err
+	}
+
+	// a rate limit is waited out without using up the only attempt
+	appendAttempts, appendStatus = 0, []int{http.StatusTooManyRequests, http.StatusNoContent}
+	equals(upload(), nil)
+	equals(appendAttempts, 2)
+
+	// rejected requests are not retried
+	uploader.Retries = 3
+	appendAttempts, appendStatus = 0, []int{http.StatusBadRequest, http.StatusNoContent}
+	equals(upload() != nil, true)
+	equals(appendAttempts, 1)
+}
@@ -1,55 +1,181 @@
 package twitter
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"net/url"
+	"strings"
 )
 
-const userFields = "user.fields=description,public_metrics,verified,profile_image_url"
+// userFields requests every field of User
+const userFields = "user.fields=description,public_metrics,verified,profile_image_url,created_at,location,url,protected,pinned_tweet_id"
 
 type userResponse struct {
-	Profile Profile `json:"data"`
+	User   user       `json:"data"`
+	Errors []APIError `json:"errors"`
 }
 
-// UserMetrics store account related metrics
+type usersResponse struct {
+	Users  []user     `json:"data"`
+	Errors []APIError `json:"errors"`
+	Meta   meta       `json:"meta"`
+}
+
+// UserMetrics store account related metrics as given by the api. User.Metrics holds them as AccountMetrics
 type UserMetrics struct {
 	Followers int `json:"followers_count"`
 	Following int `json:"following_count"`
 	Tweets    int `json:"tweet_count"`
 }
 
+// convertToAccountMetrics converts twitters public metrics of a user, which are only set if they were requested
+func convertToAccountMetrics(m *UserMetrics) *AccountMetrics {
+	if m == nil {
+		return nil
+	}
+	return &AccountMetrics{
+		Followers: m.Followers,
+		Following: m.Following,
+		Tweets:    m.Tweets,
+	}
+}
+
 // Profile contains a twitter users profile
+//
+// Deprecated: profile lookups return a User now, which is also used for the author of a tweet.
+// User is serialized with different JSON keys, e.g. handle instead of username. Use User.Profile to
+// convert a User into a Profile, e.g. to keep reading and writing previously serialized profiles
 type Profile struct {
-	Name        string      `json:"name"`
-	Handle      string      `json:"username"`
-	Picture     string      `json:"profile_image_url"`
-	Verified    bool        `json:"verified"`
-	Description string      `json:"description"`
-	Metrics     UserMetrics `json:"public_metrics"`
+	ID            string      `json:"id,omitempty"`
+	Name          string      `json:"name"`
+	Handle        string      `json:"username"`
+	Picture       string      `json:"profile_image_url"`
+	Verified      bool        `json:"verified"`
+	Description   string      `json:"description"`
+	Metrics       UserMetrics `json:"public_metrics"`
+	CreatedAt     string      `json:"created_at,omitempty"`
+	Location      string      `json:"location,omitempty"`
+	URL           string      `json:"url,omitempty"`
+	Protected     bool        `json:"protected,omitempty"`
+	PinnedTweetID string      `json:"pinned_tweet_id,omitempty"`
 }
 
-// GetProfile retrieves a users profile information
-func (tw *Client) GetProfile(userID string) (profile Profile, err error) {
-
-	uri := fmt.Sprintf("%s/users/%s?%s", apiRoot, userID, userFields)
-
-	req, err := http.NewRequest(http.MethodGet, uri, nil)
-	if err != nil {
-		return
+// Profile converts the user into the fields of the former Profile type
+func (u User) Profile() Profile {
+	profile := Profile{
+		ID:            u.ID,
+		Name:          u.Name,
+		Handle:        u.Handle,
+		Picture:       u.Picture,
+		Verified:      u.Verified,
+		Description:   u.Description,
+		CreatedAt:     u.CreatedAt,
+		Location:      u.Location,
+		URL:           u.URL,
+		Protected:     u.Protected,
+		PinnedTweetID: u.PinnedTweetID,
 	}
+	if u.Metrics != nil {
+		profile.Metrics = UserMetrics{
+			Followers: u.Metrics.Followers,
+			Following: u.Metrics.Following,
+			Tweets:    u.Metrics.Tweets,
+		}
+	}
+	return profile
+}
 
-	result, err := tw.authenticatedTwitterRequest(req)
-	if err != nil {
-		return
+// User converts the Profile into a User
+func (p Profile) User() User {
+	return User{
+		ID:            p.ID,
+		Name:          p.Name,
+		Handle:        p.Handle,
+		Picture:       p.Picture,
+		Verified:      p.Verified,
+		Description:   p.Description,
+		Metrics:       convertToAccountMetrics(&p.Metrics),
+		CreatedAt:     p.CreatedAt,
+		Location:      p.Location,
+		URL:           p.URL,
+		Protected:     p.Protected,
+		PinnedTweetID: p.PinnedTweetID,
 	}
-	defer result.Body.Close()
+}
+
+// GetProfile retrieves a users profile information.
+// The requested user.fields are the Clients UserFields if set, otherwise every field of User
+func (tw *Client) GetProfile(userID string) (User, error) {
+	uri := fmt.Sprintf("%s/users/%s?%s", apiRoot, userID, tw.userFieldsQuery())
+	return tw.getProfile(uri)
+}
+
+// GetProfileByUsername retrieves the profile of the user with the given handle
+func (tw *Client) GetProfileByUsername(username string) (User, error) {
+	uri := fmt.Sprintf("%s/users/by/username/%s?%s", apiRoot, url.PathEscape(username), tw.userFieldsQuery())
+	return tw.getProfile(uri)
+}
+
+// GetProfiles retrieves the profiles of the users with the given IDs, split into requests of 100 IDs.
+// Users that could not be found are left out and reported in errs by their ID.
+// err is only set if a request failed as a whole
+func (tw *Client) GetProfiles(userIDs []string) (profiles []User, errs map[string]error, err error) {
+	return tw.getProfiles(userIDs, func(chunk []string) string {
+		return fmt.Sprintf("%s/users?ids=%s&%s", apiRoot, strings.Join(chunk, ","), tw.userFieldsQuery())
+	})
+}
+
+// GetProfilesByUsernames retrieves the profiles of the users with the given handles, split into
+// requests of 100 names.
+// Users that could not be found are left out and reported in errs by their handle.
+// err is only set if a request failed as a whole
+func (tw *Client) GetProfilesByUsernames(usernames []string) (profiles []User, errs map[string]error, err error) {
+	return tw.getProfiles(usernames, func(chunk []string) string {
+		return fmt.Sprintf("%s/users/by?usernames=%s&%s", apiRoot, strings.Join(chunk, ","), tw.userFieldsQuery())
+	})
+}
 
+// getProfile requests a single profile
+func (tw *Client) getProfile(uri string) (profile User, err error) {
 	var response userResponse
-	err = json.NewDecoder(result.Body).Decode(&response)
+	err = tw.getJSON(uri, &response)
 	if err != nil {
 		return
 	}
+	if response.User.ID == "" && len(response.Errors) > 0 {
+		return profile, &response.Errors[0]
+	}
 
-	return response.Profile, nil
+	return convertToUser(response.User), nil
+}
+
+// getProfiles requests the profiles in chunks, using uri to build the request url for each chunk
+func (tw *Client) getProfiles(keys []string, uri func(chunk []string) string) (profiles []User, errs map[string]error, err error) {
+	errs = make(map[string]error)
+
+	for _, chunk := range chunkIDs(keys, maxLookupIDs) {
+		var response usersResponse
+		err = tw.getJSON(uri(chunk), &response)
+		if err != nil {
+			return
+		}
+
+		profiles = append(profiles, convertToUsers(response.Users)...)
+		for i, apiErr := range response.Errors {
+			key := apiErr.ResourceID
+			if key == "" {
+				key = apiErr.Value
+			}
+			errs[key] = &response.Errors[i]
+		}
+	}
+	return profiles, errs, nil
+}
+
+// convertToUsers converts a slice of twitters user objects
+func convertToUsers(users []user) []User {
+	converted := make([]User, len(users))
+	for i, u := range users {
+		converted[i] = convertToUser(u)
+	}
+	return converted
 }
@@ -0,0 +1,148 @@
+package twitter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetProfileByUsername(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.URL.Path, "/2/users/by/username/gopher")
+		w.Write([]byte(`{"data": {"id": "42", "username": "gopher", "name": "Gopher", "protected": true,
+			"pinned_tweet_id": "7", "created_at": "2009-11-10T23:00:00.000Z", "public_metrics": {"followers_count": 3}}}`))
+	})
+
+	profile, err := tw.GetProfileByUsername("gopher")
+	equals(err, nil)
+	equals(profile.ID, "42")
+	equals(profile.Protected, true)
+	equals(profile.PinnedTweetID, "7")
+	equals(profile.CreatedAt, "2009-11-10T23:00:00.000Z")
+	equals(profile.Metrics.Followers, 3)
+}
+
+func TestGetProfilesByUsernames(t *testing.T) {
+
+	var requests int
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		equals(r.URL.Path, "/2/users/by")
+		names := strings.Split(r.URL.Query().Get("usernames"), ",")
+
+		var data []string
+		for _, name := range names {
+			if name != "missing" {
+				data = append(data, fmt.Sprintf(`{"id": "id-%s", "username": "%s"}`, name, name))
+			}
+		}
+		fmt.Fprintf(w, `{"data": [%s]`, strings.Join(data, ","))
+		if len(data) < len(names) {
+			w.Write([]byte(`, "errors": [{"value": "missing", "title": "Not Found Error",
+				"type": "https://api.twitter.com/2/problems/resource-not-found"}]`))
+		}
+		w.Write([]byte("}"))
+	})
+
+	names := make([]string, 101)
+	for i := range names {
+		names[i] = fmt.Sprint("user", i)
+	}
+	names[100] = "missing"
+
+	profiles, errs, err := tw.GetProfilesByUsernames(names)
+	equals(err, nil)
+	equals(requests, 2)
+	equals(len(profiles), 100)
+	equals(profiles[5].ID, "id-user5")
+	equals(errors.Is(errs["missing"], ErrNotFound), true)
+}
+
+func TestTweetAuthorIsUser(t *testing.T) {
+
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.URL.Query().Get("user.fields"), "description,public_metrics")
+		w.Write([]byte(`{"data": {"id": "1", "text": "hi", "author_id": "42"},
+			"includes": {"users": [{"id": "42", "username": "gopher", "description": "digging",
+				"public_metrics": {"followers_count": 7}}]}}`))
+	})
+	tw.Fields = &Fields{Expansions: []string{"author_id"}, UserFields: []string{"description", "public_metrics"}}
+
+	tweet, err := tw.GetTweet("1")
+	equals(err, nil)
+	equals(tweet.Author.Description, "digging")
+	equals(tweet.Author.Metrics.Followers, 7)
+
+	author := tweet.Author.Author()
+	equals(author.Handle, "gopher")
+	equals(author.User().ID, "42")
+}
+
+func TestGetProfileUserFields(t *testing.T) {
+
+	// the Fields of tweets do not restrict user lookups
+	tw := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.URL.Query().Get("user.fields"), strings.TrimPrefix(userFields, "user.fields="))
+		w.Write([]byte(`{"data": {"id": "42", "description": "digging"}}`))
+	})
+	fields := DefaultFields()
+	tw.Fields = &fields
+
+	profile, err := tw.GetProfile("42")
+	equals(err, nil)
+	equals(profile.Description, "digging")
+
+	tw = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		equals(r.URL.Path, "/2/users/42")
+		equals(r.URL.Query().Get("user.fields"), "location")
+		w.Write([]byte(`{"data": {"id": "42", "username": "gopher", "location": "Mountain View"}}`))
+	})
+	tw.UserFields = []string{"location"}
+
+	profile, err = tw.GetProfile("42")
+	equals(err, nil)
+	equals(profile.Location, "Mountain View")
+}
+
+func TestProfileConversion(t *testing.T) {
+
+	user := User{ID: "42", Handle: "gopher", Picture: "https://example.org/gopher.png", Description: "digging",
+		Metrics: &AccountMetrics{Followers: 7}, CreatedAt: "2009-11-10T23:00:00.000Z", Location: "Mountain View",
+		URL: "https://go.dev", Protected: true, PinnedTweetID: "7"}
+
+	// the deprecated Profile keeps its JSON keys
+	data, err := json.Marshal(user.Profile())
+	equals(err, nil)
+	var fields map[string]interface{}
+	json.Unmarshal(data, &fields)
+	equals(fields["id"], "42")
+	equals(fields["username"], "gopher")
+	equals(fields["pinned_tweet_id"], "7")
+	equals(fields["profile_image_url"], "https://example.org/gopher.png")
+	equals(fields["public_metrics"].(map[string]interface{})["followers_count"], float64(7))
+
+	var profile Profile
+	equals(json.Unmarshal(data, &profile), nil)
+	// the conversion keeps every field
+	converted := profile.User()
+	equals(*converted.Metrics, *user.Metrics)
+	converted.Metrics, user.Metrics = nil, nil
+	equals(converted, user)
+}
+
+func TestUserJSON(t *testing.T) {
+
+	// fields that were not requested are left out, like in the former Author
+	data, err := json.Marshal(User{ID: "42", Handle: "gopher"})
+	equals(err, nil)
+	equals(string(data), `{"name":"","handle":"gopher","picture":"","verified":false,"id":"42"}`)
+
+	data, err = json.Marshal(User{ID: "42", Metrics: &AccountMetrics{Followers: 7}, Protected: true})
+	equals(err, nil)
+	equals(string(data), `{"name":"","handle":"","picture":"","verified":false,"id":"42",`+
+		`"metrics":{"followers":7,"following":0,"tweets":0},"protected":true}`)
+}
@@ -29,8 +29,113 @@ var ErrEmptyVariants = fmt.Errorf("got empty variants array")
 // ErrNoContentTypeMatch is returned when there is no entry with content-type video/mp4 in twitters response
 var ErrNoContentTypeMatch = fmt.Errorf("could not find supported content type entry")
 
+// ErrNoVariantWithinBitrate is returned when every variant exceeds the requested maximum bitrate
+var ErrNoVariantWithinBitrate = fmt.Errorf("could not find variant within bitrate limit")
+
+// Content types used by VideoVariant.ContentType
+const (
+	ContentTypeMP4 = "video/mp4"
+	ContentTypeHLS = "application/x-mpegURL"
+)
+
+// videoVariant is a variant of a video or animated GIF as given by the api
+type videoVariant struct {
+	Bitrate     int    `json:"bit_rate"`
+	ContentType string `json:"content_type"`
+	URL         string `json:"url"`
+}
+
+// VideoVariant is one encoding of a video or animated GIF as returned by the v2 media.fields=variants field
+type VideoVariant struct {
+	Bitrate     int    `json:"bitrate,omitempty"` // zero for HLS playlists
+	ContentType string `json:"contentType"`
+	URL         string `json:"url"`
+}
+
+// convertToVideoVariants converts twitters variants of a video or animated GIF
+func convertToVideoVariants(variants []videoVariant) []VideoVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	converted := make([]VideoVariant, len(variants))
+	for i, v := range variants {
+		converted[i] = VideoVariant{
+			Bitrate:     v.Bitrate,
+			ContentType: v.ContentType,
+			URL:         v.URL,
+		}
+	}
+	return converted
+}
+
+// VariantOptions configure how SelectVariant picks a VideoVariant
+type VariantOptions struct {
+	ContentType string // only consider variants of this type, defaults to ContentTypeMP4
+	MaxBitrate  int    // ignore variants above this bitrate if greater than zero
+	Lowest      bool   // pick the lowest instead of the highest bitrate
+}
+
+// SelectVariant returns the variant of a video or GIF matching the given options
+func (m MediaItem) SelectVariant(opts VariantOptions) (VideoVariant, error) {
+	if len(m.Variants) == 0 {
+		return VideoVariant{}, ErrEmptyVariants
+	}
+
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = ContentTypeMP4
+	}
+
+	var candidates []VideoVariant
+	for _, variant := range m.Variants {
+		if variant.ContentType == contentType {
+			candidates = append(candidates, variant)
+		}
+	}
+	if len(candidates) == 0 {
+		return VideoVariant{}, ErrNoContentTypeMatch
+	}
+
+	found := false
+	var selected VideoVariant
+	for _, variant := range candidates {
+		// HLS playlists carry no bitrate and are never excluded by the limit
+		if opts.MaxBitrate > 0 && variant.Bitrate > opts.MaxBitrate {
+			continue
+		}
+		if !found ||
+			(opts.Lowest && variant.Bitrate < selected.Bitrate) ||
+			(!opts.Lowest && variant.Bitrate > selected.Bitrate) {
+			selected = variant
+			found = true
+		}
+	}
+	if !found {
+		return VideoVariant{}, ErrNoVariantWithinBitrate
+	}
+	return selected, nil
+}
+
+// SelectVariants calls SelectVariant for every video and GIF of the tweet and returns the
+// results by media key. Media without a matching variant is left out
+func (t Tweet) SelectVariants(opts VariantOptions) map[string]VideoVariant {
+	variants := make(map[string]VideoVariant)
+	for _, item := range t.Media {
+		if item.Type != MediaTypeVideo && item.Type != MediaTypeAnimatedGIF {
+			continue
+		}
+		variant, err := item.SelectVariant(opts)
+		if err != nil {
+			continue
+		}
+		variants[item.Key] = variant
+	}
+	return variants
+}
+
 // GetVideoURL calls the v1.1/statuses/show endpoint and returns the first mp4 video
-// url associated with a tweet
+// url associated with a tweet.
+// Tweets returned by the v2 endpoints already contain all variants, see MediaItem.SelectVariant
 func (tw *Client) GetVideoURL(tweetID string) (string, error) {
 
 	endpoint := "https://api.twitter.com/1.1/statuses/show.json?id=" + tweetID
@@ -61,7 +166,7 @@ func (tw *Client) GetVideoURL(tweetID string) (string, error) {
 	}
 
 	for _, variant := range variants {
-		if variant.ContentType == "video/mp4" {
+		if variant.ContentType == ContentTypeMP4 {
 			return variant.URL, nil
 		}
 	}
@@ -0,0 +1,68 @@
+package twitter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSelectVariant(t *testing.T) {
+
+	item := MediaItem{
+		Key:  "video",
+		Type: MediaTypeVideo,
+		Variants: []VideoVariant{
+			{Bitrate: 832000, ContentType: ContentTypeMP4, URL: "medium.mp4"},
+			{ContentType: ContentTypeHLS, URL: "playlist.m3u8"},
+			{Bitrate: 2176000, ContentType: ContentTypeMP4, URL: "high.mp4"},
+			{Bitrate: 256000, ContentType: ContentTypeMP4, URL: "low.mp4"},
+		},
+	}
+
+	variant, err := item.SelectVariant(VariantOptions{})
+	equals(err, nil)
+	equals(variant.URL, "high.mp4")
+
+	variant, err = item.SelectVariant(VariantOptions{Lowest: true})
+	equals(err, nil)
+	equals(variant.URL, "low.mp4")
+
+	variant, err = item.SelectVariant(VariantOptions{MaxBitrate: 1000000})
+	equals(err, nil)
+	equals(variant.URL, "medium.mp4")
+
+	_, err = item.SelectVariant(VariantOptions{MaxBitrate: 1000})
+	equals(err, ErrNoVariantWithinBitrate)
+
+	variant, err = item.SelectVariant(VariantOptions{ContentType: ContentTypeHLS, MaxBitrate: 1000})
+	equals(err, nil)
+	equals(variant.URL, "playlist.m3u8")
+
+	_, err = item.SelectVariant(VariantOptions{ContentType: "video/webm"})
+	equals(err, ErrNoContentTypeMatch)
+
+	_, err = MediaItem{}.SelectVariant(VariantOptions{})
+	equals(err, ErrEmptyVariants)
+
+	tweet := Tweet{Media: []MediaItem{
+		item,
+		{Key: "photo", Type: MediaTypePhoto},
+		{Key: "gif", Type: MediaTypeAnimatedGIF, Variants: []VideoVariant{{ContentType: ContentTypeMP4, URL: "gif.mp4"}}},
+	}}
+	variants := tweet.SelectVariants(VariantOptions{})
+	equals(len(variants), 2)
+	equals(variants["video"].URL, "high.mp4")
+	equals(variants["gif"].URL, "gif.mp4")
+}
+
+func TestVideoVariantJSON(t *testing.T) {
+
+	var m media
+	err := json.Unmarshal([]byte(`{"media_key": "video", "type": "video",
+		"variants": [{"bit_rate": 832000, "content_type": "video/mp4", "url": "medium.mp4"}]}`), &m)
+	equals(err, nil)
+
+	// like the other fields of Tweet, variants are serialized in camelCase
+	data, err := json.Marshal(convertToMediaItem(m).Variants)
+	equals(err, nil)
+	equals(string(data), `[{"bitrate":832000,"contentType":"video/mp4","url":"medium.mp4"}]`)
+}